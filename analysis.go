@@ -0,0 +1,518 @@
+package tictactoe
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe/internal/bot"
+	"math"
+	"slices"
+)
+
+type (
+	// Analysis represents the analysis of a single Cell in which the Player whose turn it is can take their turn
+	Analysis struct {
+		// Cell is the location of the cell on the Board
+		Cell Cell `json:"cell"`
+		// Distance is the number of turns, including the turn in Cell, until Outcome is reached assuming perfect play
+		// from both players, or zero if Outcome is OutcomeUnknown
+		Distance int `json:"distance"`
+		// Outcome is the result for the Player whose turn it is should they take their turn in Cell
+		Outcome Outcome `json:"outcome"`
+		// Score is the value of taking a turn in Cell, where a higher Score is better, and is only comparable with that
+		// of other Analysis values returned by the same call to Analyze
+		Score int `json:"score"`
+	}
+
+	// Outcome represents the result of a Game for a Player
+	Outcome uint8
+)
+
+const (
+	// OutcomeUnknown represents an outcome that could not be determined
+	OutcomeUnknown Outcome = iota
+	// OutcomeWin represents an outcome where the Player wins
+	OutcomeWin
+	// OutcomeDraw represents an outcome where there is no winner
+	OutcomeDraw
+	// OutcomeLoss represents an outcome where the Player loses
+	OutcomeLoss
+)
+
+// IsValid returns whether Outcome is valid
+func (o Outcome) IsValid() bool {
+	switch o {
+	case OutcomeUnknown, OutcomeWin, OutcomeDraw, OutcomeLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns a string representation of Outcome
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeUnknown:
+		return "Unknown"
+	case OutcomeWin:
+		return "Win"
+	case OutcomeDraw:
+		return "Draw"
+	case OutcomeLoss:
+		return "Loss"
+	default:
+		return fmt.Sprintf("Unknown Outcome (%d)", o)
+	}
+}
+
+// Outcomes returns valid Outcome values
+func Outcomes() []Outcome {
+	return []Outcome{OutcomeUnknown, OutcomeWin, OutcomeDraw, OutcomeLoss}
+}
+
+// Analyze returns an Analysis of every legal Cell (see Game.LegalCells) on the Board of the given Game for the Player
+// whose turn it is, ordered such that the recommended Cell is first.
+//
+// Where the Board is small enough to be solved (i.e. its size does not exceed that supported by NewImpossibleBot), the
+// Outcome and Distance of each Cell is determined by a perfect-play search. Otherwise, only immediate wins and losses
+// can be determined and each Cell is scored heuristically based on the lines passing through it.
+//
+// An ErrGameOver is returned if Game doesn't have StateAwaitingTurn.
+func Analyze(game Game) ([]Analysis, error) {
+	return AnalyzeContext(context.Background(), game)
+}
+
+// AnalyzeContext is the same as Analyze except that the given context can be used to cancel the analysis or to impose
+// a deadline, in which case the error of context is returned.
+func AnalyzeContext(ctx context.Context, game Game) ([]Analysis, error) {
+	if game.State() != StateAwaitingTurn {
+		return nil, ErrGameOver
+	}
+	var analyses []Analysis
+	if game.Size() <= bot.MaxSizeImpossible {
+		s := newSolver(ctx, game.Board(), game)
+		analyses = s.analyze(game.Player())
+		if s.err != nil {
+			return nil, s.err
+		}
+	} else {
+		var err error
+		if analyses, err = analyzeHeuristically(ctx, game); err != nil {
+			return nil, err
+		}
+	}
+	slices.SortStableFunc(analyses, func(a, b Analysis) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return analyses, nil
+}
+
+// analyzeHeuristically returns an Analysis of every legal Cell on the Board of the given Game for the Player whose turn
+// it is, where the Score is derived from the number of cells occupied by each Player within every window of the winning
+// length passing through the Cell.
+//
+// Any Cell in which the Player would win immediately is given the highest Score and any Cell that allows the opponent
+// to win on their next turn is given the lowest.
+//
+// Under misère rules, any Cell that completes a line is given the lowest Score and any Cell that leaves the opponent
+// with no choice but to complete a line is given the highest, while the Score of all others is negated so that lines
+// are avoided.
+//
+// With gravity, the cell above a Cell also becomes available to the opponent once the Player has taken it, so it's
+// considered alongside the other candidates of the opponent.
+func analyzeHeuristically(ctx context.Context, game Game) ([]Analysis, error) {
+	board := game.Board()
+	conditions := game.Conditions()
+	gravity := game.HasGravity()
+	length := int(game.WinLength())
+	misere := game.IsMisere()
+	player := game.Player()
+	opponent := player.Next()
+
+	isWinningTurn := func(turn Turn) bool {
+		board[turn.Row][turn.Column] = turn.Player
+		won := conditions.IsWinningTurn(board, turn)
+		board[turn.Row][turn.Column] = 0
+		return won
+	}
+
+	// exposesThreat returns whether the Player taking candidate makes the cell above it available to the opponent and,
+	// if so, whether the opponent would win by taking it
+	exposesThreat := func(candidate Cell) (exposes, threat bool) {
+		if !gravity || candidate.Row == 0 {
+			return false, false
+		}
+		board[candidate.Row][candidate.Column] = player
+		threat = isWinningTurn(Turn{Cell: Cell{Column: candidate.Column, Row: candidate.Row - 1}, Player: opponent})
+		board[candidate.Row][candidate.Column] = 0
+		return true, threat
+	}
+
+	candidates := board.findLegal(gravity)
+	last := game.RemainingTurns() == 1
+	var threats Cells
+	for _, candidate := range candidates {
+		if isWinningTurn(Turn{Cell: candidate, Player: opponent}) {
+			threats = append(threats, candidate)
+		}
+	}
+
+	analyses := make([]Analysis, 0, len(candidates))
+	for i, candidate := range candidates {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		analysis := Analysis{Cell: candidate}
+		exposes, exposedThreat := exposesThreat(candidate)
+		if misere {
+			// Opponent can only complete a line in a cell that was already available to them, excluding candidate, or
+			// in any cell exposed by candidate
+			oppSafe := len(candidates) - 1 - len(threats)
+			if slices.Contains(threats, candidate) {
+				oppSafe++
+			}
+			if exposes && !exposedThreat {
+				oppSafe++
+			}
+			switch {
+			case isWinningTurn(Turn{Cell: candidate, Player: player}):
+				analysis.Distance = 1
+				analysis.Outcome = OutcomeLoss
+				analysis.Score = math.MinInt
+			case last:
+				analysis.Distance = 1
+				analysis.Outcome = OutcomeDraw
+			case oppSafe == 0:
+				analysis.Distance = 2
+				analysis.Outcome = OutcomeWin
+				analysis.Score = math.MaxInt
+			default:
+				analysis.Score = -scoreLineWindows(board, candidate, player, length)
+			}
+			analyses = append(analyses, analysis)
+			continue
+		}
+
+		switch {
+		case isWinningTurn(Turn{Cell: candidate, Player: player}):
+			analysis.Distance = 1
+			analysis.Outcome = OutcomeWin
+			analysis.Score = math.MaxInt
+		case len(threats) > 1 || (len(threats) == 1 && threats[0] != candidate) || exposedThreat:
+			analysis.Distance = 2
+			analysis.Outcome = OutcomeLoss
+			analysis.Score = math.MinInt
+		case last:
+			analysis.Distance = 1
+			analysis.Outcome = OutcomeDraw
+		default:
+			analysis.Score = scoreLineWindows(board, candidate, player, length)
+		}
+		analyses = append(analyses, analysis)
+	}
+	return analyses, nil
+}
+
+// scoreLineWindows returns a score for the given Player taking a turn in the given Cell based on each window of the
+// given length in any row, column, or diagonal on board that contains the Cell.
+//
+// Windows are scored exponentially by the number of cells occupied by only one Player so that extending a line is
+// favoured, while blocking the line of the opponent is worth slightly less than extending a line of the same length.
+func scoreLineWindows(board Board, cell Cell, player Player, length int) (score int) {
+	for _, d := range [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} {
+		for offset := 0; offset < length; offset++ {
+			startRow, startCol := int(cell.Row)-offset*d[0], int(cell.Column)-offset*d[1]
+			endRow, endCol := startRow+(length-1)*d[0], startCol+(length-1)*d[1]
+			if !board.isInBounds(startRow, startCol) || !board.isInBounds(endRow, endCol) {
+				continue
+			}
+			var own, opp int
+			for i := 0; i < length; i++ {
+				switch board[startRow+i*d[0]][startCol+i*d[1]] {
+				case 0:
+				case player:
+					own++
+				default:
+					opp++
+				}
+			}
+			switch {
+			case opp == 0:
+				score += 1 << min(3*own, 30)
+			case own == 0:
+				score += 1<<min(3*opp, 30) - 1
+			}
+		}
+	}
+	return
+}
+
+const (
+	solverExact uint8 = iota
+	solverLowerBound
+	solverUpperBound
+)
+
+type (
+	// solver performs a perfect-play negamax search with alpha-beta pruning, move ordering, and a transposition table.
+	//
+	// Values are relative to the Player taking the turn, with a win being worth more the fewer turns it takes and vice
+	// versa for a loss. As each turn fills exactly one cell, a position always has the same ply within a single search,
+	// so values can safely be shared via the transposition table.
+	//
+	// Under misère rules, a turn that completes a line is valued as an immediate loss rather than a win. With gravity,
+	// a turn can only be taken in the lowest empty cell of each column.
+	solver struct {
+		board      Board
+		cells      Cells
+		conditions Conditions
+		ctx        context.Context
+		empty      int
+		err        error
+		gravity    bool
+		key        uint64
+		maxValue   int
+		misere     bool
+		nodes      int
+		table      map[uint64]solverEntry
+		weights    []int
+	}
+
+	solverEntry struct {
+		best  int
+		bound uint8
+		value int
+	}
+)
+
+// newSolver returns a solver for a copy of the given Board, whose size must not exceed 32 cells so that each position
+// can be encoded within a single key.
+//
+// Any search is abandoned once the given context is done, with its error being recorded on the solver.
+func newSolver(ctx context.Context, board Board, game Game) *solver {
+	s := &solver{
+		board:      board.Copy(),
+		conditions: game.Conditions(),
+		ctx:        ctx,
+		gravity:    game.HasGravity(),
+		maxValue:   game.MaxTurns() + 1,
+		misere:     game.IsMisere(),
+		table:      make(map[uint64]solverEntry),
+	}
+	length := int(game.WinLength())
+	for row, cols := range s.board {
+		for col, player := range cols {
+			cell := Cell{
+				Column: uint8(col),
+				Row:    uint8(row),
+			}
+			if player == 0 {
+				s.empty++
+			} else {
+				s.key |= uint64(player) << (2 * len(s.cells))
+			}
+			s.cells = append(s.cells, cell)
+			s.weights = append(s.weights, countLineWindows(s.board, cell, length))
+		}
+	}
+	return s
+}
+
+// search returns the value of the current position for the given Player along with the index of the best cell to
+// take, where ply is the number of turns taken since the search began.
+func (s *solver) search(player Player, ply, alpha, beta int) (int, int) {
+	if s.nodes++; s.nodes%1024 == 0 && s.err == nil {
+		s.err = s.ctx.Err()
+	}
+	if s.err != nil {
+		return 0, -1
+	}
+
+	originalAlpha := alpha
+	best := -1
+	if entry, ok := s.table[s.key]; ok {
+		switch entry.bound {
+		case solverExact:
+			return entry.value, entry.best
+		case solverLowerBound:
+			alpha = max(alpha, entry.value)
+		case solverUpperBound:
+			beta = min(beta, entry.value)
+		}
+		if alpha >= beta {
+			return entry.value, entry.best
+		}
+		best = entry.best
+	}
+
+	candidates, win := s.candidates(player, best)
+	if win {
+		return s.maxValue - (ply + 1), candidates[0]
+	}
+
+	value := -s.maxValue
+	for _, i := range candidates {
+		var candidateValue int
+		if s.misere && s.isWinningTurn(i, player) {
+			candidateValue = -(s.maxValue - (ply + 1))
+		} else {
+			s.place(i, player)
+			if s.empty > 0 {
+				candidateValue, _ = s.search(player.Next(), ply+1, -beta, -alpha)
+				candidateValue = -candidateValue
+			}
+			s.remove(i, player)
+			if s.err != nil {
+				return 0, -1
+			}
+		}
+
+		if candidateValue > value {
+			value, best = candidateValue, i
+		}
+		if alpha = max(alpha, value); alpha >= beta {
+			break
+		}
+	}
+
+	entry := solverEntry{
+		best:  best,
+		value: value,
+	}
+	switch {
+	case value <= originalAlpha:
+		entry.bound = solverUpperBound
+	case value >= beta:
+		entry.bound = solverLowerBound
+	default:
+		entry.bound = solverExact
+	}
+	s.table[s.key] = entry
+
+	return value, best
+}
+
+// analyze returns an Analysis of every legal cell for the given Player, where each cell is searched with a full window
+// so that its value, and therefore its Outcome and Distance, is exact.
+func (s *solver) analyze(player Player) []Analysis {
+	analyses := make([]Analysis, 0, s.empty)
+	for i, cell := range s.cells {
+		if !s.isLegal(cell) {
+			continue
+		}
+
+		analysis := Analysis{Cell: cell}
+		if s.isWinningTurn(i, player) {
+			analysis.Score = s.maxValue - 1
+			if s.misere {
+				analysis.Score = -analysis.Score
+			}
+		} else if s.empty > 1 {
+			s.place(i, player)
+			analysis.Score, _ = s.search(player.Next(), 1, -s.maxValue, s.maxValue)
+			analysis.Score = -analysis.Score
+			s.remove(i, player)
+			if s.err != nil {
+				return nil
+			}
+		}
+
+		switch {
+		case analysis.Score > 0:
+			analysis.Distance = s.maxValue - analysis.Score
+			analysis.Outcome = OutcomeWin
+		case analysis.Score < 0:
+			analysis.Distance = s.maxValue + analysis.Score
+			analysis.Outcome = OutcomeLoss
+		default:
+			analysis.Distance = s.empty
+			analysis.Outcome = OutcomeDraw
+		}
+		analyses = append(analyses, analysis)
+	}
+	return analyses
+}
+
+// candidates returns the indices of all legal cells ordered such that the cells most likely to be the best turn for
+// the given Player are first.
+//
+// If a winning turn exists, only its index is returned along with true. Otherwise, any cell that would prevent the
+// opponent from winning on their next turn comes first, followed by the given best cell, where valid, then the
+// remaining cells in order of the number of lines passing through them.
+//
+// Under misère rules, any cell that would complete a line for the given Player comes last instead, as it loses.
+func (s *solver) candidates(player Player, best int) ([]int, bool) {
+	candidates := make([]int, 0, s.empty)
+	priorities := make(map[int]int, s.empty)
+	for i, cell := range s.cells {
+		if !s.isLegal(cell) {
+			continue
+		}
+		switch {
+		case s.misere && s.isWinningTurn(i, player):
+			priorities[i] = -1
+		case s.isWinningTurn(i, player):
+			return []int{i}, true
+		case !s.misere && s.isWinningTurn(i, player.Next()):
+			priorities[i] = 2
+		case i == best:
+			priorities[i] = 1
+		}
+		candidates = append(candidates, i)
+	}
+	slices.SortStableFunc(candidates, func(a, b int) int {
+		if priorities[a] != priorities[b] {
+			return priorities[b] - priorities[a]
+		}
+		return s.weights[b] - s.weights[a]
+	})
+	return candidates, false
+}
+
+// isLegal returns whether a turn can be taken in the given Cell, which must be empty and, with gravity, also be the
+// lowest empty cell of its column
+func (s *solver) isLegal(cell Cell) bool {
+	if s.board[cell.Row][cell.Column] != 0 {
+		return false
+	}
+	return !s.gravity || int(cell.Row) == len(s.board)-1 || s.board[cell.Row+1][cell.Column] != 0
+}
+
+func (s *solver) isWinningTurn(i int, player Player) bool {
+	s.place(i, player)
+	won := s.conditions.IsWinningTurn(s.board, Turn{Cell: s.cells[i], Player: player})
+	s.remove(i, player)
+	return won
+}
+
+func (s *solver) place(i int, player Player) {
+	cell := s.cells[i]
+	s.board[cell.Row][cell.Column] = player
+	s.empty--
+	s.key |= uint64(player) << (2 * i)
+}
+
+func (s *solver) remove(i int, player Player) {
+	cell := s.cells[i]
+	s.board[cell.Row][cell.Column] = 0
+	s.empty++
+	s.key &^= uint64(player) << (2 * i)
+}
+
+// countLineWindows returns the number of windows of the given length in any row, column, or diagonal on board that
+// contain the given Cell.
+func countLineWindows(board Board, cell Cell, length int) (count int) {
+	for _, d := range [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} {
+		for offset := 0; offset < length; offset++ {
+			startRow, startCol := int(cell.Row)-offset*d[0], int(cell.Column)-offset*d[1]
+			endRow, endCol := startRow+(length-1)*d[0], startCol+(length-1)*d[1]
+			if board.isInBounds(startRow, startCol) && board.isInBounds(endRow, endCol) {
+				count++
+			}
+		}
+	}
+	return
+}
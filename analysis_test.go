@@ -0,0 +1,151 @@
+package tictactoe
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		position string
+		opts     []Option
+		count    int
+		cell     string
+		outcome  Outcome
+		distance int
+	}{
+		{
+			name:     "empty",
+			position: ".../.../... x",
+			count:    9,
+			cell:     "a1",
+			outcome:  OutcomeDraw,
+			distance: 9,
+		},
+		{
+			name:     "win",
+			position: "xx./oo./... x",
+			count:    5,
+			cell:     "c1",
+			outcome:  OutcomeWin,
+			distance: 1,
+		},
+		{
+			name:     "forced win",
+			position: "xo./.../... x",
+			count:    7,
+			cell:     "a2",
+			outcome:  OutcomeWin,
+			distance: 5,
+		},
+		{
+			name:     "block",
+			position: "xx./o../... o",
+			count:    6,
+			cell:     "c1",
+			outcome:  OutcomeLoss,
+			distance: 4,
+		},
+		{
+			name:     "misere avoid line",
+			position: "xx./oo./... x",
+			opts:     []Option{WithMisere()},
+			count:    5,
+			cell:     "a3",
+			outcome:  OutcomeDraw,
+			distance: 5,
+		},
+		{
+			name:     "4x4",
+			position: "x.../o.../x.../o... x",
+			count:    12,
+			cell:     "b1",
+			outcome:  OutcomeDraw,
+			distance: 12,
+		},
+		{
+			name:     "heuristic win",
+			position: "xxx../ooo../...../...../..... x 4",
+			count:    19,
+			cell:     "d1",
+			outcome:  OutcomeWin,
+			distance: 1,
+		},
+		{
+			name:     "heuristic block",
+			position: "xxx../oo.../...../...../..... o 4",
+			count:    20,
+			cell:     "d1",
+			outcome:  OutcomeUnknown,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(append([]Option{WithPosition(tc.position)}, tc.opts...)...)
+			analyses, err := Analyze(g)
+			if err != nil {
+				t.Fatalf("Analyze() returned unexpected error: %v", err)
+			}
+			if len(analyses) != tc.count {
+				t.Fatalf("Analyze() returned %d analyses, want %d", len(analyses), tc.count)
+			}
+			want := Analysis{Cell: mustParseCell(t, tc.cell), Distance: tc.distance, Outcome: tc.outcome}
+			if got := analyses[0]; got.Cell != want.Cell || got.Distance != want.Distance ||
+				got.Outcome != want.Outcome {
+				t.Errorf("Analyze()[0] = %+v, want %+v", got, want)
+			}
+			for i := 1; i < len(analyses); i++ {
+				if analyses[i].Score > analyses[i-1].Score {
+					t.Errorf("Analyze()[%d] has Score %d greater than that of preceding analysis %d", i,
+						analyses[i].Score, analyses[i-1].Score)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyze_Blocks(t *testing.T) {
+	g := MustStart(WithPosition("xxx../oo.../...../...../..... o 4"))
+	analyses, err := Analyze(g)
+	if err != nil {
+		t.Fatalf("Analyze() returned unexpected error: %v", err)
+	}
+	for _, a := range analyses[1:] {
+		if a.Outcome != OutcomeLoss || a.Distance != 2 {
+			t.Errorf("Analyze() has %+v, want Outcome %v and Distance 2 for any cell not blocking win", a, OutcomeLoss)
+		}
+	}
+}
+
+func TestAnalyze_MisereCompletesLine(t *testing.T) {
+	for _, position := range []string{"xx./oo./... x", "xxx../ooo../...../...../..... x 4"} {
+		g := MustStart(WithMisere(), WithPosition(position))
+		analyses, err := Analyze(g)
+		if err != nil {
+			t.Fatalf("Analyze() returned unexpected error: %v", err)
+		}
+		want := Analysis{Cell: Cell{Column: g.WinLength() - 1}, Distance: 1, Outcome: OutcomeLoss}
+		if got := analyses[len(analyses)-1]; got.Cell != want.Cell || got.Distance != want.Distance ||
+			got.Outcome != want.Outcome {
+			t.Errorf("Analyze() for %q has last analysis %+v, want %+v", position, got, want)
+		}
+	}
+}
+
+func TestAnalyzeContext_Cancelled(t *testing.T) {
+	for _, size := range []uint8{4, 6} {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := AnalyzeContext(ctx, MustStart(WithSize(size))); !errors.Is(err, context.Canceled) {
+			t.Errorf("AnalyzeContext() for size %d returned error %v, want %v", size, err, context.Canceled)
+		}
+	}
+}
+
+func TestAnalyze_GameOver(t *testing.T) {
+	g := MustStart(WithPosition("xxx/oo./... -"))
+	if _, err := Analyze(g); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Analyze() returned error %v, want %v", err, ErrGameOver)
+	}
+}
@@ -0,0 +1,528 @@
+package arena
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/internal/bot"
+	"github.com/neocotic/go-tic-tac-toe/ratings"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// matchup represents the games to be played between two bots on a board of a given size
+type matchup struct {
+	bot, opponent string
+	size          uint8
+}
+
+// job represents a single game within a matchup
+type job struct {
+	game    int
+	index   int
+	matchup int
+	seed    int64
+}
+
+// jobResult represents the result of a job from the perspective of the first bot within its matchup
+type jobResult struct {
+	err               error
+	index             int
+	matchup           int
+	moves, oppMoves   int
+	moveTime, oppTime time.Duration
+	outcome           tictactoe.Outcome
+	variant           string
+}
+
+// result contains the aggregated results of all games played by a bot, either within a matchup or overall where
+// Opponent is empty
+type result struct {
+	Bot      string  `json:"bot"`
+	Opponent string  `json:"opponent,omitempty"`
+	Size     uint8   `json:"size,omitempty"`
+	Games    int     `json:"games"`
+	Wins     int     `json:"wins"`
+	Draws    int     `json:"draws"`
+	Losses   int     `json:"losses"`
+	Score    float64 `json:"score"`
+	// ScoreLow and ScoreHigh are the bounds of the 95% confidence interval for Score
+	ScoreLow  float64 `json:"scoreLow"`
+	ScoreHigh float64 `json:"scoreHigh"`
+	// MoveTime and OpponentMoveTime are the average time taken by each bot per move
+	MoveTime         time.Duration `json:"moveTime"`
+	OpponentMoveTime time.Duration `json:"opponentMoveTime,omitempty"`
+
+	moves, oppMoves   int
+	moveTime, oppTime time.Duration
+}
+
+// add aggregates the given jobResult into result
+func (r *result) add(jr jobResult) {
+	r.Games++
+	switch jr.outcome {
+	case tictactoe.OutcomeWin:
+		r.Wins++
+	case tictactoe.OutcomeDraw:
+		r.Draws++
+	case tictactoe.OutcomeLoss:
+		r.Losses++
+	}
+	r.moves += jr.moves
+	r.moveTime += jr.moveTime
+	r.oppMoves += jr.oppMoves
+	r.oppTime += jr.oppTime
+}
+
+// complete calculates the score, its confidence interval, and average move times for result once all games have been
+// added
+func (r *result) complete() {
+	if r.Games == 0 {
+		return
+	}
+	n := float64(r.Games)
+	r.Score = (float64(r.Wins) + float64(r.Draws)/2) / n
+	// Each game scores 1, 0.5, or 0 so the normal approximation is based on the variance of those scores
+	variance := (float64(r.Wins)*math.Pow(1-r.Score, 2) + float64(r.Draws)*math.Pow(0.5-r.Score, 2) +
+		float64(r.Losses)*math.Pow(r.Score, 2)) / n
+	margin := confidenceZ * math.Sqrt(variance/n)
+	r.ScoreLow = max(r.Score-margin, 0)
+	r.ScoreHigh = min(r.Score+margin, 1)
+	if r.moves > 0 {
+		r.MoveTime = r.moveTime / time.Duration(r.moves)
+	}
+	if r.oppMoves > 0 {
+		r.OpponentMoveTime = r.oppTime / time.Duration(r.oppMoves)
+	}
+}
+
+// defaultBots contains the names of the built-in bots that play each other by default, excluding the MCTS bot since its
+// default budget would make each matchup take minutes
+var defaultBots = []string{bot.NameEasy, bot.NameNormal, bot.NameHard, bot.NameImpossible}
+
+// confidenceZ is the z-score for a 95% confidence interval
+const confidenceZ = 1.96
+
+const (
+	flagNameBots         = "bots"
+	flagNameCSV          = "csv"
+	flagNameGames        = "games"
+	flagNameGravity      = "gravity"
+	flagNameHelp         = "help"
+	flagNameJSON         = "json"
+	flagNameMCTSPlayouts = "mcts-playouts"
+	flagNameMCTSTime     = "mcts-time"
+	flagNameMisere       = "misere"
+	flagNameRatings      = "ratings"
+	flagNameSeed         = "seed"
+	flagNameSizes        = "sizes"
+	flagNameWinLength    = "win-length"
+	flagNameWorkers      = "workers"
+
+	flagInvalidReasonBotMaxSizeExceeded = "bot max board size exceeded"
+	flagInvalidReasonBotsRequired       = "at least two different bots required"
+	flagInvalidReasonOutOfRange         = "value out of range"
+	flagInvalidReasonParse              = "parse error"
+	flagInvalidReasonWrite              = "write error"
+)
+
+func handleInvalidFlag(name string, value any, reason string) {
+	fmt.Printf(`invalid value "%v" for flag -%s: %s
+`, value, name, reason)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func newBot(name string, player tictactoe.Player, mctsBudget tictactoe.MCTSBudget) (tictactoe.Bot, error) {
+	if name == bot.NameMCTS {
+		return tictactoe.NewMCTSBot(player, mctsBudget), nil
+	}
+	return tictactoe.NewBot(name, player)
+}
+
+// playGame plays the game of the given job, where colours and the starting player alternate between games so that
+// neither bot within the matchup has an advantage
+func playGame(m matchup, j job, winLength uint8, variants []tictactoe.Option,
+	mctsBudget tictactoe.MCTSBudget) jobResult {
+	jr := jobResult{index: j.index, matchup: j.matchup}
+	player, starter := tictactoe.PlayerOne, tictactoe.PlayerOne
+	if j.game%2 == 1 {
+		player = tictactoe.PlayerTwo
+	}
+	if (j.game/2)%2 == 1 {
+		starter = tictactoe.PlayerTwo
+	}
+
+	b, err := newBot(m.bot, player, mctsBudget)
+	if err != nil {
+		jr.err = err
+		return jr
+	}
+	opp, err := newBot(m.opponent, player.Next(), mctsBudget)
+	if err != nil {
+		jr.err = err
+		return jr
+	}
+	opts := []tictactoe.Option{
+		tictactoe.WithSeed(j.seed),
+		tictactoe.WithSize(m.size),
+		tictactoe.WithStarterPlayer(starter),
+		tictactoe.WithBots(b, opp),
+	}
+	if winLength > 0 {
+		opts = append(opts, tictactoe.WithWinLength(winLength))
+	}
+	g, err := tictactoe.Start(append(opts, variants...)...)
+	if err != nil {
+		jr.err = err
+		return jr
+	}
+
+	for g.IsBotTurn() {
+		current := g.Player()
+		start := time.Now()
+		if _, _, err = g.AllowBotTurn(); err != nil {
+			jr.err = err
+			return jr
+		}
+		if elapsed := time.Since(start); current == player {
+			jr.moves++
+			jr.moveTime += elapsed
+		} else {
+			jr.oppMoves++
+			jr.oppTime += elapsed
+		}
+	}
+
+	jr.variant = ratings.Variant(g)
+	switch g.State() {
+	case tictactoe.StateDraw:
+		jr.outcome = tictactoe.OutcomeDraw
+	case tictactoe.StateWon:
+		if g.Player() == player {
+			jr.outcome = tictactoe.OutcomeWin
+		} else {
+			jr.outcome = tictactoe.OutcomeLoss
+		}
+	}
+	return jr
+}
+
+// rate updates the given ratings with the result of each job, in the order in which the jobs were created so that the
+// ratings are reproducible regardless of the order in which the games finished
+func rate(r *ratings.Ratings, matchups []matchup, played []jobResult) {
+	for _, jr := range played {
+		var score float64
+		switch jr.outcome {
+		case tictactoe.OutcomeWin:
+			score = 1
+		case tictactoe.OutcomeDraw:
+			score = 0.5
+		}
+		m := matchups[jr.matchup]
+		// Names of bots within a matchup are always different so cannot fail
+		_, _, _ = r.Record(jr.variant, m.bot, m.opponent, score)
+	}
+}
+
+// standings returns the overall result for each bot across all of the given matchup results, ordered by score
+func standings(results []*result) []*result {
+	totals := make(map[string]*result)
+	total := func(name string) *result {
+		if r, ok := totals[name]; ok {
+			return r
+		}
+		r := &result{Bot: name}
+		totals[name] = r
+		return r
+	}
+	for _, r := range results {
+		t := total(r.Bot)
+		t.Games += r.Games
+		t.Wins += r.Wins
+		t.Draws += r.Draws
+		t.Losses += r.Losses
+		t.moves += r.moves
+		t.moveTime += r.moveTime
+
+		t = total(r.Opponent)
+		t.Games += r.Games
+		t.Wins += r.Losses
+		t.Draws += r.Draws
+		t.Losses += r.Wins
+		t.moves += r.oppMoves
+		t.moveTime += r.oppTime
+	}
+
+	var s []*result
+	for _, t := range totals {
+		t.complete()
+		s = append(s, t)
+	}
+	slices.SortFunc(s, func(a, b *result) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Bot, b.Bot)
+	})
+	return s
+}
+
+func writeCSV(w io.Writer, results []*result) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{{"bot", "opponent", "size", "games", "wins", "draws", "losses", "score", "scoreLow",
+		"scoreHigh", "moveTime", "opponentMoveTime"}}
+	for _, r := range results {
+		records = append(records, []string{
+			r.Bot,
+			r.Opponent,
+			strconv.Itoa(int(r.Size)),
+			strconv.Itoa(r.Games),
+			strconv.Itoa(r.Wins),
+			strconv.Itoa(r.Draws),
+			strconv.Itoa(r.Losses),
+			strconv.FormatFloat(r.Score, 'f', 4, 64),
+			strconv.FormatFloat(r.ScoreLow, 'f', 4, 64),
+			strconv.FormatFloat(r.ScoreHigh, 'f', 4, 64),
+			strconv.FormatInt(int64(r.MoveTime), 10),
+			strconv.FormatInt(int64(r.OpponentMoveTime), 10),
+		})
+	}
+	return cw.WriteAll(records)
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(w io.Writer, results []*result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+func writeTables(w io.Writer, results, standings []*result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SIZE\tBOT\tOPPONENT\tGAMES\tWINS\tDRAWS\tLOSSES\tSCORE\t95% CI\tMOVE TIME\tOPPONENT MOVE TIME")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%dx%[1]d\t%s\t%s\t%d\t%d\t%d\t%d\t%.1f%%\t%.1f%%-%.1f%%\t%v\t%v\n", r.Size, r.Bot, r.Opponent,
+			r.Games, r.Wins, r.Draws, r.Losses, r.Score*100, r.ScoreLow*100, r.ScoreHigh*100, r.MoveTime,
+			r.OpponentMoveTime)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "RANK\tBOT\tGAMES\tWINS\tDRAWS\tLOSSES\tSCORE\t95% CI\tMOVE TIME")
+	for i, r := range standings {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%d\t%.1f%%\t%.1f%%-%.1f%%\t%v\n", i+1, r.Bot, r.Games, r.Wins, r.Draws,
+			r.Losses, r.Score*100, r.ScoreLow*100, r.ScoreHigh*100, r.MoveTime)
+	}
+	return tw.Flush()
+}
+
+// Main runs the arena using the command-line flags, printing the results of each matchup alongside overall standings,
+// and exits the process if any flag is invalid.
+//
+// Any Bot registered using tictactoe.RegisterBot before Main is called can be played by name, so an arena including
+// custom bots can be built by a main function that registers them before calling Main.
+func Main() {
+	var (
+		botsFlag, csvFlag, jsonFlag, ratingsFlag, sizesFlag string
+		gravityFlag, helpFlag, misereFlag                   bool
+		gamesFlag, mctsPlayoutsFlag, winLengthFlag          uint
+		workersFlag                                         int
+		mctsTimeFlag                                        time.Duration
+		seedFlag                                            int64
+	)
+
+	flag.StringVar(&botsFlag, flagNameBots, strings.Join(defaultBots, ","), "comma-separated names of bots to play")
+	flag.StringVar(&csvFlag, flagNameCSV, "", "write results as CSV to file")
+	flag.UintVar(&gamesFlag, flagNameGames, 100, "number of games per matchup on each board size")
+	flag.BoolVar(&gravityFlag, flagNameGravity, false, "drop each turn to the lowest empty cell of its column")
+	flag.BoolVar(&helpFlag, flagNameHelp, false, "print help")
+	flag.StringVar(&jsonFlag, flagNameJSON, "", "write results as JSON to file")
+	flag.UintVar(&mctsPlayoutsFlag, flagNameMCTSPlayouts, 0, `maximum playouts per turn for "mcts" bot (default 10000)`)
+	flag.DurationVar(&mctsTimeFlag, flagNameMCTSTime, 0, `maximum time per turn for "mcts" bot (default 1s)`)
+	flag.BoolVar(&misereFlag, flagNameMisere, false, "play misère variant where completing a line loses")
+	flag.StringVar(&ratingsFlag, flagNameRatings, "", "add results to ratings stored in file")
+	flag.Int64Var(&seedFlag, flagNameSeed, 0, "seed for randomness, printed to replay arena (default random)")
+	flag.StringVar(&sizesFlag, flagNameSizes, "3", "comma-separated sizes of boards")
+	flag.UintVar(&winLengthFlag, flagNameWinLength, 0, "number of cells in a row required to win (default size of board)")
+	flag.IntVar(&workersFlag, flagNameWorkers, runtime.NumCPU(), "number of games played in parallel")
+	flag.Parse()
+
+	if helpFlag {
+		flag.Usage()
+		return
+	}
+
+	var names []string
+	known := tictactoe.BotNames()
+	for _, name := range strings.Split(botsFlag, ",") {
+		if name = strings.TrimSpace(name); !slices.Contains(known, name) {
+			handleInvalidFlag(flagNameBots, botsFlag, fmt.Sprintf("%s (unknown bot %q)", flagInvalidReasonParse, name))
+		} else if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) < 2 {
+		handleInvalidFlag(flagNameBots, botsFlag, flagInvalidReasonBotsRequired)
+	}
+
+	if winLengthFlag > 0 && winLengthFlag < uint(tictactoe.MinWinLength) {
+		handleInvalidFlag(flagNameWinLength, winLengthFlag, flagInvalidReasonOutOfRange)
+	}
+
+	var sizes []uint8
+	for _, s := range strings.Split(sizesFlag, ",") {
+		size, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8)
+		if err != nil {
+			handleInvalidFlag(flagNameSizes, sizesFlag, flagInvalidReasonParse)
+		} else if size < uint64(tictactoe.MinSize) || size < uint64(winLengthFlag) {
+			handleInvalidFlag(flagNameSizes, sizesFlag, flagInvalidReasonOutOfRange)
+		}
+		sizes = append(sizes, uint8(size))
+	}
+
+	if gamesFlag == 0 {
+		handleInvalidFlag(flagNameGames, gamesFlag, flagInvalidReasonOutOfRange)
+	}
+	if workersFlag < 1 {
+		handleInvalidFlag(flagNameWorkers, workersFlag, flagInvalidReasonOutOfRange)
+	}
+
+	mctsBudget := tictactoe.MCTSBudget{
+		Duration: mctsTimeFlag,
+		Playouts: int(mctsPlayoutsFlag),
+	}
+
+	var matchups []matchup
+	for _, size := range sizes {
+		for i, name := range names {
+			for _, opponent := range names[i+1:] {
+				matchups = append(matchups, matchup{bot: name, opponent: opponent, size: size})
+			}
+		}
+	}
+	for _, name := range names {
+		b, _ := newBot(name, tictactoe.PlayerOne, mctsBudget)
+		if maxSize := b.MaxSize(); slices.Max(sizes) > maxSize {
+			reason := fmt.Sprintf("%q %s (%v)", name, flagInvalidReasonBotMaxSizeExceeded, maxSize)
+			handleInvalidFlag(flagNameSizes, sizesFlag, reason)
+		}
+	}
+
+	var rs *ratings.Ratings
+	if ratingsFlag != "" {
+		var err error
+		if rs, err = ratings.Load(ratingsFlag); err != nil {
+			handleInvalidFlag(flagNameRatings, ratingsFlag, fmt.Sprintf("%s (%v)", flagInvalidReasonParse, err))
+		}
+	}
+
+	var variants []tictactoe.Option
+	if gravityFlag {
+		variants = append(variants, tictactoe.WithGravity())
+	}
+	if misereFlag {
+		variants = append(variants, tictactoe.WithMisere())
+	}
+
+	seed := seedFlag
+	// Seed is only random where not given so that an explicit seed of zero can also be used to replay arena
+	seedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == flagNameSeed {
+			seedSet = true
+		}
+	})
+	if !seedSet {
+		seed = rand.Int63()
+	}
+	// Seed of each game is derived up front so that results are reproducible regardless of the number of workers
+	r := rand.New(rand.NewSource(seed))
+	var jobs []job
+	for i := range matchups {
+		for game := 0; game < int(gamesFlag); game++ {
+			jobs = append(jobs, job{game: game, index: len(jobs), matchup: i, seed: r.Int63()})
+		}
+	}
+
+	jobChan := make(chan job)
+	resultChan := make(chan jobResult)
+	var wg sync.WaitGroup
+	for i := 0; i < workersFlag; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobChan {
+				resultChan <- playGame(matchups[j.matchup], j, uint8(winLengthFlag), variants, mctsBudget)
+			}
+		}()
+	}
+	go func() {
+		for _, j := range jobs {
+			jobChan <- j
+		}
+		close(jobChan)
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	results := make([]*result, len(matchups))
+	for i, m := range matchups {
+		results[i] = &result{Bot: m.bot, Opponent: m.opponent, Size: m.size}
+	}
+	played := make([]jobResult, len(jobs))
+	for jr := range resultChan {
+		if jr.err != nil {
+			// Built-in bots should never cause errors to return
+			panic(jr.err)
+		}
+		results[jr.matchup].add(jr)
+		played[jr.index] = jr
+	}
+	for _, r := range results {
+		r.complete()
+	}
+
+	if err := writeTables(os.Stdout, results, standings(results)); err != nil {
+		panic(err)
+	}
+	if csvFlag != "" {
+		if err := writeFile(csvFlag, func(w io.Writer) error { return writeCSV(w, results) }); err != nil {
+			handleInvalidFlag(flagNameCSV, csvFlag, fmt.Sprintf("%s (%v)", flagInvalidReasonWrite, err))
+		}
+	}
+	if jsonFlag != "" {
+		if err := writeFile(jsonFlag, func(w io.Writer) error { return writeJSON(w, results) }); err != nil {
+			handleInvalidFlag(flagNameJSON, jsonFlag, fmt.Sprintf("%s (%v)", flagInvalidReasonWrite, err))
+		}
+	}
+	if rs != nil {
+		rate(rs, matchups, played)
+		if err := rs.Save(ratingsFlag); err != nil {
+			handleInvalidFlag(flagNameRatings, ratingsFlag, fmt.Sprintf("%s (%v)", flagInvalidReasonWrite, err))
+		}
+	}
+
+	fmt.Printf("\nseed: %d\n", seed)
+}
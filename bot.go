@@ -1,27 +1,44 @@
 package tictactoe
 
 import (
+	"context"
+	"errors"
 	"github.com/neocotic/go-tic-tac-toe/internal/bot"
 	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
 )
 
-// Bot represents a machine-controlled player of a Game whose sole purpose is to beat a human Player
-type Bot interface {
-	// MaxSize returns the maximum board size supported by the Bot
-	MaxSize() uint8
-	// Name returns the name of the Bot
-	Name() string
-	// Player returns the Player for which the Bot is playing
-	Player() Player
-	// Turn allows the Bot to check the Board for the best possible turn and returns the Cell representing that turns
-	// location on Board.
-	//
-	// The Board provided is not a copy so a Bot must never mutate it or risk corrupting the Game.
-	//
-	// Turn is only ever called if there is at least one possible turn to make so a Bot is always able to return
-	// something. However, an error may be returned in some cases (e.g. ErrConditionInvalid).
-	Turn(board Board, game Game) (Cell, error)
-}
+type (
+	// Bot represents a machine-controlled player of a Game whose sole purpose is to beat a human Player
+	Bot interface {
+		// MaxSize returns the maximum board size (i.e. number of rows or columns) supported by the Bot
+		MaxSize() uint8
+		// Name returns the name of the Bot
+		Name() string
+		// Player returns the Player for which the Bot is playing
+		Player() Player
+		// Turn allows the Bot to check the Board for the best possible turn and returns the Cell representing that
+		// turns location on Board.
+		//
+		// The Board provided is not a copy so a Bot must never mutate it or risk corrupting the Game.
+		//
+		// Turn is only ever called if there is at least one possible turn to make so a Bot is always able to return
+		// something. However, an error may be returned in some cases (e.g. ErrConditionInvalid).
+		Turn(board Board, game Game) (Cell, error)
+	}
+
+	// ContextBot is an optional interface that may be implemented by a Bot to support cancellation of, and deadlines
+	// for, taking their turn
+	ContextBot interface {
+		Bot
+		// TurnContext is the same as Turn except that it must stop checking the Board and return the error of the
+		// given context as soon as possible once context is done.
+		TurnContext(ctx context.Context, board Board, game Game) (Cell, error)
+	}
+)
 
 type easyBot struct {
 	player Player
@@ -39,8 +56,8 @@ func (b *easyBot) Player() Player {
 	return b.player
 }
 
-func (b *easyBot) Turn(board Board, _ Game) (Cell, error) {
-	return board.FindEmpty().Random(), nil
+func (b *easyBot) Turn(board Board, game Game) (Cell, error) {
+	return board.findLegal(game.HasGravity()).RandomFrom(game.Rand()), nil
 }
 
 // NewEasyBot returns a new Bot with a very easy difficulty
@@ -65,8 +82,15 @@ func (b *normalBot) Player() Player {
 }
 
 func (b *normalBot) Turn(board Board, game Game) (Cell, error) {
-	candidates := board.FindEmpty()
+	candidates := board.findLegal(game.HasGravity())
 	conditions := game.Conditions()
+	if game.IsMisere() {
+		// Completing a line loses so any cell that does is only taken if there's no alternative
+		if safe := findNonWinningCells(board, candidates, conditions, b.player); len(safe) > 0 {
+			return safe.RandomFrom(game.Rand()), nil
+		}
+		return candidates.RandomFrom(game.Rand()), nil
+	}
 	for _, candidate := range candidates {
 		nextBoard := board.Copy()
 		nextBoard[candidate.Row][candidate.Column] = b.player
@@ -79,7 +103,7 @@ func (b *normalBot) Turn(board Board, game Game) (Cell, error) {
 			return candidate, nil
 		}
 	}
-	return candidates.Random(), nil
+	return candidates.RandomFrom(game.Rand()), nil
 }
 
 // NewNormalBot returns a new Bot with a normal difficulty
@@ -105,10 +129,25 @@ func (b *hardBot) Player() Player {
 }
 
 func (b *hardBot) Turn(board Board, game Game) (Cell, error) {
-	candidates := board.FindEmpty()
+	return b.TurnContext(context.Background(), board, game)
+}
+
+func (b *hardBot) TurnContext(ctx context.Context, board Board, game Game) (Cell, error) {
+	if game.IsMisere() {
+		return b.misereTurn(ctx, board, game)
+	}
+
+	candidates := board.findLegal(game.HasGravity())
 	conditions := game.Conditions()
-	var oppWinner *Cell
+	var (
+		exposed   Cells
+		oppWinner *Cell
+	)
 	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return Cell{}, err
+		}
+
 		nextBoard := board.Copy()
 		nextBoard[candidate.Row][candidate.Column] = b.player
 
@@ -120,7 +159,7 @@ func (b *hardBot) Turn(board Board, game Game) (Cell, error) {
 			return candidate, nil
 		}
 
-		oppCandidates := nextBoard.FindEmpty()
+		oppCandidates := nextBoard.findLegal(game.HasGravity())
 		for _, oppCandidate := range oppCandidates {
 			oppBoard := nextBoard.Copy()
 			oppBoard[oppCandidate.Row][oppCandidate.Column] = b.opponent
@@ -129,9 +168,15 @@ func (b *hardBot) Turn(board Board, game Game) (Cell, error) {
 				Cell:   oppCandidate,
 				Player: b.opponent,
 			}
-			if conditions.IsWinningTurn(oppBoard, turn) {
+			if !conditions.IsWinningTurn(oppBoard, turn) {
+				continue
+			}
+			if slices.Contains(candidates, oppCandidate) {
 				copyCandidate := oppCandidate
 				oppWinner = &copyCandidate
+			} else {
+				// Only possible with gravity, where candidate would allow the opponent to win in the cell above it
+				exposed = append(exposed, candidate)
 			}
 		}
 	}
@@ -139,7 +184,38 @@ func (b *hardBot) Turn(board Board, game Game) (Cell, error) {
 	if oppWinner != nil {
 		return *oppWinner, nil
 	}
-	return candidates.Random(), nil
+	if len(exposed) > 0 && len(exposed) < len(candidates) {
+		candidates = slices.DeleteFunc(candidates, func(cell Cell) bool {
+			return slices.Contains(exposed, cell)
+		})
+	}
+	return candidates.RandomFrom(game.Rand()), nil
+}
+
+// misereTurn returns the Cell for the turn of the Bot under misère rules, avoiding any cell that completes a line and
+// favouring any cell that leaves the opponent with no choice but to complete a line.
+func (b *hardBot) misereTurn(ctx context.Context, board Board, game Game) (Cell, error) {
+	candidates := board.findLegal(game.HasGravity())
+	conditions := game.Conditions()
+	safe := findNonWinningCells(board, candidates, conditions, b.player)
+	if len(safe) == 0 {
+		return candidates.RandomFrom(game.Rand()), nil
+	}
+
+	nextBoard := board.Copy()
+	for _, candidate := range safe {
+		if err := ctx.Err(); err != nil {
+			return Cell{}, err
+		}
+
+		nextBoard[candidate.Row][candidate.Column] = b.player
+		oppCandidates := nextBoard.findLegal(game.HasGravity())
+		if len(oppCandidates) > 0 && len(findNonWinningCells(nextBoard, oppCandidates, conditions, b.opponent)) == 0 {
+			return candidate, nil
+		}
+		nextBoard[candidate.Row][candidate.Column] = 0
+	}
+	return safe.RandomFrom(game.Rand()), nil
 }
 
 // NewHardBot returns a new Bot with a hard difficulty
@@ -150,16 +226,9 @@ func NewHardBot(player Player) Bot {
 	}
 }
 
-type (
-	impossibleBot struct {
-		player Player
-	}
-
-	impossibleChoice struct {
-		cell         Cell
-		depth, value int
-	}
-)
+type impossibleBot struct {
+	player Player
+}
 
 func (b *impossibleBot) MaxSize() uint8 {
 	return bot.MaxSizeImpossible
@@ -174,78 +243,454 @@ func (b *impossibleBot) Player() Player {
 }
 
 func (b *impossibleBot) Turn(board Board, game Game) (Cell, error) {
-	lastTurn, _ := game.LastTurn()
-	choice, err := b.minimax(board, game, lastTurn, true, b.player, 0)
-	return choice.cell, err
+	return b.TurnContext(context.Background(), board, game)
 }
 
-func (b *impossibleBot) minimax(board Board, game Game, lastTurn Turn, max bool, player Player, depth int) (impossibleChoice, error) {
-	candidates := board.FindEmpty()
-	conditions := game.Conditions()
-	if winner, err := conditions.FindWinner(board); err != nil {
-		return impossibleChoice{}, err
-	} else if winner == b.player {
-		return impossibleChoice{
-			cell:  lastTurn.Cell,
-			depth: depth,
-			value: (game.MaxTurns() + 1) - depth,
-		}, nil
-	} else if winner == b.player.Next() {
-		return impossibleChoice{
-			cell:  lastTurn.Cell,
-			depth: depth,
-			value: -(game.MaxTurns() + 1) + depth,
-		}, nil
-	} else if len(candidates) == 0 {
-		return impossibleChoice{
-			cell:  lastTurn.Cell,
-			depth: depth,
-			value: 0,
-		}, nil
-	}
-
-	var choices []impossibleChoice
-	for _, candidate := range candidates {
-		nextBoard := board.Copy()
-		nextBoard[candidate.Row][candidate.Column] = player
+func (b *impossibleBot) TurnContext(ctx context.Context, board Board, game Game) (Cell, error) {
+	s := newSolver(ctx, board, game)
+	_, best := s.search(b.player, 0, -s.maxValue, s.maxValue)
+	if s.err != nil {
+		return Cell{}, s.err
+	}
+	return s.cells[best], nil
+}
 
-		turn := Turn{
-			Cell:   candidate,
-			Player: player,
+// NewImpossibleBot returns a new Bot with an impossible-to-beat difficulty
+func NewImpossibleBot(player Player) Bot {
+	return &impossibleBot{player}
+}
+
+// DefaultMCTSBudget is the MCTSBudget used by a Bot created by NewMCTSBot when given an empty MCTSBudget
+var DefaultMCTSBudget = MCTSBudget{
+	Duration: time.Second,
+	Playouts: 10_000,
+}
+
+// MCTSBudget controls the amount of work performed by a Bot created by NewMCTSBot each time it takes a turn, where the
+// Bot stops searching as soon as any limit has been reached
+type MCTSBudget struct {
+	// Duration is the maximum amount of time spent searching, where zero means no limit
+	Duration time.Duration
+	// Playouts is the maximum number of playouts (i.e. simulated games), where zero means no limit
+	Playouts int
+}
+
+const (
+	// mctsExploration is the exploration constant used when selecting a node using UCT
+	mctsExploration = math.Sqrt2
+	// mctsMaxPlayoutTurns is the maximum number of turns taken within a single playout before it's considered a draw
+	mctsMaxPlayoutTurns = 256
+	// mctsRadius is the maximum distance from an occupied cell that a cell can be to be considered as a candidate
+	mctsRadius = 2
+)
+
+type (
+	mctsBot struct {
+		budget MCTSBudget
+		player Player
+	}
+
+	mctsNode struct {
+		cell     Cell
+		children []*mctsNode
+		parent   *mctsNode
+		player   Player
+		untried  Cells
+		visits   int
+		wins     float64
+		winner   Player
+		over     bool
+	}
+
+	mctsSearch struct {
+		board      Board
+		conditions Conditions
+		empty      Cells
+		filled     int
+		gravity    bool
+		maxTurns   int
+		misere     bool
+		placed     Cells
+		pruned     bool
+		rand       *rand.Rand
+		root       *mctsNode
+	}
+)
+
+func (b *mctsBot) MaxSize() uint8 {
+	return bot.MaxSizeMCTS
+}
+
+func (b *mctsBot) Name() string {
+	return bot.NameMCTS
+}
+
+func (b *mctsBot) Player() Player {
+	return b.player
+}
+
+func (b *mctsBot) Turn(board Board, game Game) (Cell, error) {
+	return b.TurnContext(context.Background(), board, game)
+}
+
+func (b *mctsBot) TurnContext(ctx context.Context, board Board, game Game) (Cell, error) {
+	s := &mctsSearch{
+		board:      board.Copy(),
+		conditions: game.Conditions(),
+		empty:      board.FindEmpty(),
+		gravity:    game.HasGravity(),
+		maxTurns:   game.MaxTurns(),
+		misere:     game.IsMisere(),
+		rand:       game.Rand(),
+	}
+	s.filled = s.maxTurns - len(s.empty)
+	// Candidates can only be pruned to cells near occupied cells where a win always requires a line of adjacent cells,
+	// which cannot be known for custom conditions
+	s.pruned = isStandardConditions(s.conditions)
+
+	// Always take a winning turn or prevent the opponent from winning on their next turn before searching, except under
+	// misère rules where completing a line loses so it's left to the search to avoid doing so
+	if !s.misere {
+		for _, player := range []Player{b.player, b.player.Next()} {
+			for _, cell := range s.board.findLegal(s.gravity) {
+				if s.isWinningTurn(Turn{Cell: cell, Player: player}) {
+					return cell, nil
+				}
+			}
+		}
+	}
+
+	s.root = s.newNode(nil, Turn{Player: b.player.Next()})
+	if len(s.root.untried) == 1 {
+		return s.root.untried[0], nil
+	}
+
+	budget := b.budget
+	if budget == (MCTSBudget{}) {
+		budget = DefaultMCTSBudget
+	}
+	start := time.Now()
+	for playouts := 0; budget.Playouts <= 0 || playouts < budget.Playouts; playouts++ {
+		if err := ctx.Err(); err != nil {
+			return Cell{}, err
 		}
-		choice, err := b.minimax(nextBoard, game, turn, !max, player.Next(), depth+1)
-		if err != nil {
-			return choice, err
+		if budget.Duration > 0 && time.Since(start) >= budget.Duration {
+			break
 		}
-		choice.cell = turn.Cell
-		choices = append(choices, choice)
+		s.iterate()
 	}
 
-	minChoice := impossibleChoice{
-		depth: depth,
-		value: int(math.Pow(float64(game.MaxTurns()+1), 2)),
+	var best *mctsNode
+	for _, child := range s.root.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+	if best == nil {
+		return s.root.untried.RandomFrom(s.rand), nil
+	}
+	return best.cell, nil
+}
+
+// iterate performs a single iteration of the search, selecting and expanding a node before simulating the rest of the
+// game from it and propagating the result back up the tree.
+func (s *mctsSearch) iterate() {
+	node := s.root
+	for !node.over && len(node.untried) == 0 && len(node.children) > 0 {
+		node = node.selectChild()
+		s.place(Turn{Cell: node.cell, Player: node.player})
 	}
-	maxChoice := impossibleChoice{
-		depth: depth,
-		value: -int(math.Pow(float64(game.MaxTurns()+1), 2)),
+
+	if !node.over && len(node.untried) > 0 {
+		i := s.rand.Intn(len(node.untried))
+		turn := Turn{Cell: node.untried[i], Player: node.player.Next()}
+		node.untried = slices.Delete(node.untried, i, i+1)
+		s.place(turn)
+		child := s.newNode(node, turn)
+		node.children = append(node.children, child)
+		node = child
+	}
+
+	winner := node.winner
+	if !node.over {
+		winner = s.playout(node.player.Next())
 	}
 
-	for _, choice := range choices {
-		switch {
-		case max && choice.value > maxChoice.value:
-			maxChoice = choice
-		case !max && choice.value < minChoice.value:
-			minChoice = choice
+	for n := node; n != nil; n = n.parent {
+		n.visits++
+		switch winner {
+		case n.player:
+			n.wins++
+		case 0:
+			n.wins += 0.5
 		}
 	}
 
-	if max {
-		return maxChoice, nil
+	for _, cell := range s.placed {
+		s.board[cell.Row][cell.Column] = 0
 	}
-	return minChoice, nil
+	s.filled -= len(s.placed)
+	s.placed = s.placed[:0]
 }
 
-// NewImpossibleBot returns a new Bot with an impossible-to-beat difficulty
-func NewImpossibleBot(player Player) Bot {
-	return &impossibleBot{player}
+func (s *mctsSearch) isWinningTurn(turn Turn) bool {
+	s.board[turn.Row][turn.Column] = turn.Player
+	won := s.conditions.IsWinningTurn(s.board, turn)
+	s.board[turn.Row][turn.Column] = 0
+	return won
+}
+
+// newNode returns a node for the given Turn, which has already been placed on the Board, including its candidates
+// being all empty cells near an occupied cell where pruned, all empty cells otherwise or, with gravity, the lowest
+// empty cell within each column.
+func (s *mctsSearch) newNode(parent *mctsNode, turn Turn) *mctsNode {
+	node := &mctsNode{
+		cell:   turn.Cell,
+		parent: parent,
+		player: turn.Player,
+	}
+	if parent != nil && s.conditions.IsWinningTurn(s.board, turn) {
+		node.over = true
+		node.winner = s.winner(turn.Player)
+		return node
+	}
+	if s.filled >= s.maxTurns {
+		node.over = true
+		return node
+	}
+
+	if s.gravity || !s.pruned {
+		node.untried = s.board.findLegal(s.gravity)
+		return node
+	}
+	if s.filled == 0 {
+		center := Cell{
+			Column: uint8(len(s.board[0]) / 2),
+			Row:    uint8(len(s.board) / 2),
+		}
+		node.untried = Cells{center}
+		return node
+	}
+	for row, cols := range s.board {
+		for col, player := range cols {
+			if player == 0 && s.isNearOccupied(row, col) {
+				node.untried = append(node.untried, Cell{
+					Column: uint8(col),
+					Row:    uint8(row),
+				})
+			}
+		}
+	}
+	return node
+}
+
+func (s *mctsSearch) isNearOccupied(row, col int) bool {
+	for dRow := -mctsRadius; dRow <= mctsRadius; dRow++ {
+		for dCol := -mctsRadius; dCol <= mctsRadius; dCol++ {
+			if s.board.playerAt(row+dRow, col+dCol) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *mctsSearch) place(turn Turn) {
+	s.board[turn.Row][turn.Column] = turn.Player
+	s.filled++
+	s.placed = append(s.placed, turn.Cell)
+}
+
+// playout simulates the rest of the game by taking random turns, starting with the given Player, and returns the
+// winning Player, where there is one.
+func (s *mctsSearch) playout(player Player) Player {
+	if s.gravity {
+		return s.playoutWithGravity(player)
+	}
+	empty := slices.Clone(s.empty)
+	for turns := 0; turns < mctsMaxPlayoutTurns && s.filled < s.maxTurns; {
+		i := s.rand.Intn(len(empty))
+		cell := empty[i]
+		empty[i] = empty[len(empty)-1]
+		empty = empty[:len(empty)-1]
+		if s.board[cell.Row][cell.Column] != 0 {
+			continue
+		}
+
+		turn := Turn{Cell: cell, Player: player}
+		s.place(turn)
+		if s.conditions.IsWinningTurn(s.board, turn) {
+			return s.winner(player)
+		}
+		player = player.Next()
+		turns++
+	}
+	return 0
+}
+
+// playoutWithGravity is the same as playout except that each turn is taken in the lowest empty cell of a random column
+// that isn't full.
+func (s *mctsSearch) playoutWithGravity(player Player) Player {
+	for turns := 0; turns < mctsMaxPlayoutTurns && s.filled < s.maxTurns; turns++ {
+		turn := Turn{Cell: s.board.findLegal(true).RandomFrom(s.rand), Player: player}
+		s.place(turn)
+		if s.conditions.IsWinningTurn(s.board, turn) {
+			return s.winner(player)
+		}
+		player = player.Next()
+	}
+	return 0
+}
+
+// winner returns the winning Player where the given Player has completed a line, which is their opponent under misère
+// rules
+func (s *mctsSearch) winner(player Player) Player {
+	if s.misere {
+		return player.Next()
+	}
+	return player
+}
+
+// selectChild returns the child with the greatest upper confidence bound (UCT)
+func (n *mctsNode) selectChild() *mctsNode {
+	var (
+		best      *mctsNode
+		bestValue float64
+		logVisits = math.Log(float64(n.visits))
+	)
+	for _, child := range n.children {
+		value := child.wins/float64(child.visits) + mctsExploration*math.Sqrt(logVisits/float64(child.visits))
+		if best == nil || value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	return best
+}
+
+// NewMCTSBot returns a new Bot that uses Monte Carlo tree search, limited by the given MCTSBudget, which is suitable for
+// boards of any size.
+//
+// DefaultMCTSBudget is used if budget is empty.
+func NewMCTSBot(player Player, budget MCTSBudget) Bot {
+	return &mctsBot{
+		budget: budget,
+		player: player,
+	}
+}
+
+// findNonWinningCells returns the given candidates on board in which a turn taken by the given Player would not satisfy
+// any of the given winning conditions
+func findNonWinningCells(board Board, candidates Cells, conditions Conditions, player Player) Cells {
+	board = board.Copy()
+	var cells Cells
+	for _, candidate := range candidates {
+		board[candidate.Row][candidate.Column] = player
+		if !conditions.IsWinningTurn(board, Turn{Cell: candidate, Player: player}) {
+			cells = append(cells, candidate)
+		}
+		board[candidate.Row][candidate.Column] = 0
+	}
+	return cells
+}
+
+// isStandardConditions returns whether the given Conditions are only those returned by StandardConditions
+func isStandardConditions(conditions Conditions) bool {
+	for _, c := range conditions {
+		switch c.(type) {
+		case *diagonalCondition, *horizontalCondition, *verticalCondition:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func botTurn(ctx context.Context, bot Bot, board Board, game Game) (Cell, error) {
+	if err := ctx.Err(); err != nil {
+		return Cell{}, err
+	}
+	if cb, ok := bot.(ContextBot); ok {
+		return cb.TurnContext(ctx, board, game)
+	}
+	cell, err := bot.Turn(board, game)
+	if err == nil {
+		err = ctx.Err()
+	}
+	return cell, err
+}
+
+// BotFactory returns a new Bot playing for the given Player
+type BotFactory func(player Player) Bot
+
+var botRegistry = struct {
+	factories map[string]BotFactory
+	mu        sync.RWMutex
+}{factories: make(map[string]BotFactory)}
+
+// BotNames returns the names of all built-in and registered bots in alphabetical order
+func BotNames() []string {
+	botRegistry.mu.RLock()
+	defer botRegistry.mu.RUnlock()
+	names := []string{bot.NameEasy, bot.NameHard, bot.NameImpossible, bot.NameMCTS, bot.NameNormal}
+	for name := range botRegistry.factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewBot returns a new built-in or registered Bot with the given name playing for player.
+//
+// Where name is that of the built-in MCTS Bot, DefaultMCTSBudget is used.
+//
+// An ErrBotNotFound is returned if no Bot is built-in or registered with name.
+func NewBot(name string, player Player) (Bot, error) {
+	if b := newBuiltInBot(name, player); b != nil {
+		return b, nil
+	}
+	botRegistry.mu.RLock()
+	factory, ok := botRegistry.factories[name]
+	botRegistry.mu.RUnlock()
+	if !ok {
+		return nil, fmtBotNotFoundErr(name)
+	}
+	return factory(player), nil
+}
+
+// RegisterBot registers the given factory so that a custom Bot can be created using NewBot, including when restoring a
+// Snapshot (see WithSnapshot), as well as by any tool that accepts the name of a Bot.
+//
+// The name of each Bot returned by factory should be name. RegisterBot is typically called by an init function.
+//
+// An error is returned in following cases:
+//   - ErrBotRegistered if name is already in use by a built-in or registered Bot
+//   - name is empty or factory is nil
+func RegisterBot(name string, factory BotFactory) error {
+	if name == "" || factory == nil {
+		return errors.New("bot name and factory are required")
+	}
+	botRegistry.mu.Lock()
+	defer botRegistry.mu.Unlock()
+	if _, ok := botRegistry.factories[name]; ok || newBuiltInBot(name, PlayerOne) != nil {
+		return fmtBotRegisteredErr(name)
+	}
+	botRegistry.factories[name] = factory
+	return nil
+}
+
+func newBuiltInBot(name string, player Player) Bot {
+	switch name {
+	case bot.NameEasy:
+		return NewEasyBot(player)
+	case bot.NameHard:
+		return NewHardBot(player)
+	case bot.NameImpossible:
+		return NewImpossibleBot(player)
+	case bot.NameMCTS:
+		return NewMCTSBot(player, MCTSBudget{})
+	case bot.NameNormal:
+		return NewNormalBot(player)
+	default:
+		return nil
+	}
 }
@@ -0,0 +1,128 @@
+package tictactoe
+
+import "testing"
+
+func TestImpossibleBot(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		position string
+		opts     []Option
+		cell     string
+	}{
+		{"win", "xx./oo./... x", nil, "c1"},
+		{"block", "xx./o../... o", nil, "c1"},
+		{"win over block", "x.x./oo../x.../o... o 3", nil, "c2"},
+		{"4x4 block", "xxx./oo../.o../.... o", nil, "d1"},
+		{"misere avoid line", "xx./oo./... x", []Option{WithMisere()}, "a3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(append([]Option{WithPosition(tc.position)}, tc.opts...)...)
+			cell, err := NewImpossibleBot(g.Player()).Turn(g.Board(), g)
+			if err != nil {
+				t.Fatalf("Turn() returned unexpected error: %v", err)
+			}
+			if want := mustParseCell(t, tc.cell); cell != want {
+				t.Errorf("Turn() = %s, want %s", FormatCell(cell), tc.cell)
+			}
+		})
+	}
+}
+
+func TestMCTSBot(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		position string
+		opts     []Option
+		budget   MCTSBudget
+		cell     string
+	}{
+		{"win", "xx./oo./... x", nil, MCTSBudget{}, "c1"},
+		{"block", "xx./o../... o", nil, MCTSBudget{}, "c1"},
+		{"gravity block", ".../o../xx. o", []Option{WithGravity()}, MCTSBudget{}, "c3"},
+		{"gravity win", "..../..../.x../xoo. o 3", []Option{WithGravity()}, MCTSBudget{}, "d4"},
+		{"bounded playouts", "x../.../... o", []Option{WithSeed(1)}, MCTSBudget{Playouts: 2_000}, "b2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(append([]Option{WithPosition(tc.position)}, tc.opts...)...)
+			cell, err := NewMCTSBot(g.Player(), tc.budget).Turn(g.Board(), g)
+			if err != nil {
+				t.Fatalf("Turn() returned unexpected error: %v", err)
+			}
+			if want := mustParseCell(t, tc.cell); cell != want {
+				t.Errorf("Turn() = %s, want %s", FormatCell(cell), tc.cell)
+			}
+		})
+	}
+}
+
+func TestMCTSBot_CustomCondition(t *testing.T) {
+	// Taking any corner makes two threats under cornersCondition, but no corner is near an occupied cell so would never
+	// be a candidate if pruned as with the standard conditions
+	g := MustStart(WithPosition("x....../......./......./...o.../......./......./....... x"),
+		WithCondition(cornersCondition{}), WithSeed(1))
+	cell, err := NewMCTSBot(PlayerOne, MCTSBudget{Playouts: 5_000}).Turn(g.Board(), g)
+	if err != nil {
+		t.Fatalf("Turn() returned unexpected error: %v", err)
+	}
+	if !(cornersCondition{}).isCorner(g.Board(), cell) {
+		t.Errorf("Turn() = %s, want corner", FormatCell(cell))
+	}
+}
+
+func TestImpossibleBot_NeverLoses(t *testing.T) {
+	for _, opponent := range []string{"easy", "normal", "hard", "impossible"} {
+		for _, player := range Players() {
+			for seed := int64(1); seed <= 10; seed++ {
+				opp, err := NewBot(opponent, player.Next())
+				if err != nil {
+					t.Fatalf("NewBot() returned unexpected error: %v", err)
+				}
+				g := MustStart(WithBots(NewImpossibleBot(player), opp), WithSeed(seed))
+				for g.IsBotTurn() {
+					if _, _, err = g.AllowBotTurn(); err != nil {
+						t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+					}
+				}
+				if g.State() == StateWon && g.Player() != player {
+					t.Errorf("impossible bot as player[%d] lost to %q with seed %d: %s", player, opponent, seed,
+						Format(g))
+				}
+				if opponent == "impossible" && g.State() != StateDraw {
+					t.Errorf("impossible bot against itself did not draw: %s", Format(g))
+				}
+			}
+		}
+	}
+}
+
+// cornersCondition is a Condition where a Player wins by taking three corners of Board
+type cornersCondition struct{}
+
+func (c cornersCondition) FindWinner(board Board) Player {
+	for _, player := range Players() {
+		if c.countCorners(board, player) >= 3 {
+			return player
+		}
+	}
+	return 0
+}
+
+func (c cornersCondition) IsWinningTurn(board Board, turn Turn) bool {
+	return c.isCorner(board, turn.Cell) && c.countCorners(board, turn.Player) >= 3
+}
+
+func (c cornersCondition) countCorners(board Board, player Player) int {
+	last := len(board) - 1
+	var count int
+	for _, cell := range [][2]int{{0, 0}, {0, last}, {last, 0}, {last, last}} {
+		if board[cell[0]][cell[1]] == player {
+			count++
+		}
+	}
+	return count
+}
+
+func (c cornersCondition) isCorner(board Board, cell Cell) bool {
+	last := uint8(len(board) - 1)
+	return (cell.Row == 0 || cell.Row == last) && (cell.Column == 0 || cell.Column == last)
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/neocotic/go-tic-tac-toe/arena"
+
+func main() {
+	arena.Main()
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/charmbracelet/bubbles/help"
@@ -10,19 +13,30 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/neocotic/go-tic-tac-toe"
 	"github.com/neocotic/go-tic-tac-toe/internal/bot"
+	"github.com/neocotic/go-tic-tac-toe/ratings"
+	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 type keyMap struct {
 	down    key.Binding
 	choose  key.Binding
+	command key.Binding
 	help    key.Binding
+	hint    key.Binding
 	left    key.Binding
 	quit    key.Binding
+	redo    key.Binding
+	resign  key.Binding
 	restart key.Binding
 	right   key.Binding
+	save    key.Binding
+	undo    key.Binding
 	up      key.Binding
 }
 
@@ -32,43 +46,123 @@ func (km keyMap) ShortHelp() []key.Binding {
 
 func (km keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{km.up, km.down, km.left, km.right, km.choose}, // First column
-		{km.help, km.restart, km.quit},                 // Second column
+		{km.up, km.down, km.left, km.right, km.choose, km.command},                    // First column
+		{km.undo, km.redo, km.hint, km.resign, km.save, km.help, km.restart, km.quit}, // Second column
 	}
 }
 
 type styles struct {
 	board          lipgloss.Style
 	cell           lipgloss.Style
+	cellAlt        lipgloss.Style
+	cellColumn     lipgloss.Style
 	cellError      lipgloss.Style
 	cellFocus      lipgloss.Style
+	cellHint       lipgloss.Style
+	cellWin        lipgloss.Style
 	help           lipgloss.Style
 	message        lipgloss.Style
 	messageDraw    lipgloss.Style
+	messageError   lipgloss.Style
 	messageForfeit lipgloss.Style
 	messageWin     lipgloss.Style
+	ruler          lipgloss.Style
+}
+
+// withMessageWidth returns a copy of styles where all message styles have the given width
+func (s styles) withMessageWidth(width int) styles {
+	s.message = s.message.Width(width)
+	s.messageDraw = s.messageDraw.Width(width)
+	s.messageError = s.messageError.Width(width)
+	s.messageForfeit = s.messageForfeit.Width(width)
+	s.messageWin = s.messageWin.Width(width)
+	return s
+}
+
+// cellSize represents the size at which each cell on the board is rendered
+type cellSize struct {
+	border        bool
+	height, width int
+}
+
+// cellSizes contains each supported cellSize in order of preference, where the first to fit the terminal is used
+var cellSizes = []cellSize{
+	{border: true, height: 5, width: 15},
+	{border: true, height: 3, width: 7},
+	{height: 1, width: 3},
+	{height: 1, width: 1},
+}
+
+func (cs cellSize) apply(style lipgloss.Style) lipgloss.Style {
+	style = style.Width(cs.width).Height(cs.height)
+	if cs.border {
+		style = style.Border(lipgloss.OuterHalfBlockBorder(), true, true, true, true)
+	}
+	return style
+}
+
+func (cs cellSize) outerHeight() int {
+	if cs.border {
+		return cs.height + 2
+	}
+	return cs.height
+}
+
+func (cs cellSize) outerWidth() int {
+	if cs.border {
+		return cs.width + 2
+	}
+	return cs.width
+}
+
+// boardLayout represents how the board is rendered, including the range of rows and columns visible within the
+// viewport
+type boardLayout struct {
+	cellSize
+	colStart, cols int
+	rowStart, rows int
+	rulers         bool
 }
 
 type botTurnMsg struct {
 	err    error
+	game   tictactoe.Game
 	player tictactoe.Player
 	state  tictactoe.State
 }
 
-type botTurnStartedMsg struct{}
+type hintMsg struct {
+	analyses []tictactoe.Analysis
+	err      error
+	game     tictactoe.Game
+	turns    int
+}
 
 type model struct {
+	botDelay         time.Duration
+	botErr           error
 	botTurn          bool
 	botTurnChan      chan botTurnMsg
+	cancel           context.CancelFunc
+	command          string
+	commandErr       error
+	commanding       bool
+	ctx              context.Context
 	cursorX, cursorY uint8
 	err              error
-	forfeit          bool
 	game             tictactoe.Game
 	gameOver         bool
+	height, width    int
 	help             help.Model
+	hint             *tictactoe.Cell
+	hinting          bool
 	keys             keyMap
 	pack             tictactoe.Pack
 	player           tictactoe.Player
+	saveErr          error
+	savePath         string
+	saved            bool
+	seeder           *seeder
 	state            tictactoe.State
 	styles           styles
 	zone             *zone.Manager
@@ -76,46 +170,71 @@ type model struct {
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(tea.SetWindowTitle("tic-tac-toe"), m.allowBotTurn())
+	var variants []string
+	if m.game.HasGravity() {
+		variants = append(variants, "gravity")
+	}
+	if m.game.IsMisere() {
+		variants = append(variants, "misère")
+	}
+	title := "tic-tac-toe"
+	if len(variants) > 0 {
+		title += " (" + strings.Join(variants, ", ") + ")"
+	}
+	_, cmd := m.allowBotTurn()
+	return tea.Batch(tea.SetWindowTitle(title), cmd)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case botTurnMsg:
-		if !m.gameOver {
-			if msg.err != nil {
-				// Built-in bots should never cause errors to return
-				panic(msg.err)
-			}
+		// Ignore any bot turn for a previous game (e.g. before restart)
+		if msg.game == m.game && !m.gameOver {
+			m.botErr = msg.err
 			m.botTurn = false
 			m.gameOver = msg.state != tictactoe.StateAwaitingTurn
 			m.player = msg.player
 			m.state = msg.state
+			// Bot is not asked to take its turn again if it failed, otherwise it would likely fail again and again
+			if m.botErr == nil {
+				return m.allowBotTurn()
+			}
 		}
-	case botTurnStartedMsg:
-		if !m.gameOver {
-			m.botTurn = true
-			m.err = nil
+	case hintMsg:
+		// Ignore any hint for a previous game or turn
+		if msg.game == m.game && msg.turns == len(m.game.Turns()) && m.hinting {
+			m.hinting = false
+			if msg.err == nil && len(msg.analyses) > 0 {
+				m.hint = &msg.analyses[0].Cell
+			}
 		}
 	case tea.KeyMsg:
+		m.botErr = nil
+		m.hint, m.hinting = nil, false
+		m.saveErr, m.saved = nil, false
+		if m.commanding {
+			return m.updateCommand(msg)
+		}
+		m.commandErr = nil
 		switch {
 		case key.Matches(msg, m.keys.choose):
 			if !(m.botTurn || m.gameOver) {
 				m.state, m.player, m.err = m.game.Play(tictactoe.Turn{
-					Cell: tictactoe.Cell{
-						Column: m.cursorX,
-						Row:    m.cursorY,
-					},
+					Cell:   m.focusCell(),
 					Player: m.player,
 				})
 				m.gameOver = m.state != tictactoe.StateAwaitingTurn
-				return m, m.allowBotTurn()
+				return m.allowBotTurn()
+			}
+		case key.Matches(msg, m.keys.command):
+			if !(m.botTurn || m.gameOver) {
+				m.command, m.commanding = "", true
 			}
 		case key.Matches(msg, m.keys.up):
 			if !(m.botTurn || m.gameOver) {
 				m.err = nil
 				if m.cursorY == 0 {
-					m.cursorY = m.game.Size() - 1
+					m.cursorY = m.game.Rows() - 1
 				} else {
 					m.cursorY--
 				}
@@ -123,7 +242,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.down):
 			if !(m.botTurn || m.gameOver) {
 				m.err = nil
-				if m.cursorY == m.game.Size()-1 {
+				if m.cursorY == m.game.Rows()-1 {
 					m.cursorY = 0
 				} else {
 					m.cursorY++
@@ -133,7 +252,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if !(m.botTurn || m.gameOver) {
 				m.err = nil
 				if m.cursorX == 0 {
-					m.cursorX = m.game.Size() - 1
+					m.cursorX = m.game.Columns() - 1
 				} else {
 					m.cursorX--
 				}
@@ -141,63 +260,106 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.right):
 			if !(m.botTurn || m.gameOver) {
 				m.err = nil
-				if m.cursorX == m.game.Size()-1 {
+				if m.cursorX == m.game.Columns()-1 {
 					m.cursorX = 0
 				} else {
 					m.cursorX++
 				}
 			}
+		case key.Matches(msg, m.keys.undo):
+			if !m.botTurn {
+				m.state, m.player, m.err = m.game.Undo()
+				m.gameOver = m.state != tictactoe.StateAwaitingTurn
+				return m.allowBotTurn()
+			}
+		case key.Matches(msg, m.keys.redo):
+			if !m.botTurn {
+				m.state, m.player, m.err = m.game.Redo()
+				m.gameOver = m.state != tictactoe.StateAwaitingTurn
+				return m.allowBotTurn()
+			}
+		case key.Matches(msg, m.keys.hint):
+			if !(m.botTurn || m.gameOver) {
+				m.hinting = true
+				return m, analyzeGame(m.ctx, m.game)
+			}
+		case key.Matches(msg, m.keys.resign):
+			if !(m.botTurn || m.gameOver) {
+				m.state, m.player, m.err = m.game.Resign(m.player)
+				m.gameOver = m.state != tictactoe.StateAwaitingTurn
+			}
+		case key.Matches(msg, m.keys.save):
+			if !m.botTurn {
+				m.saveErr = saveGame(m.savePath, m.game)
+				m.saved = m.saveErr == nil
+			}
 		case key.Matches(msg, m.keys.restart):
-			nm := initModel(m.pack, m.zone)
-			return nm, nm.allowBotTurn()
+			m.cancel()
+			return initModel(m.pack, m.seeder, m.savePath, m.botDelay, m.zone).allowBotTurn()
 		case key.Matches(msg, m.keys.help):
 			m.help.ShowAll = !m.help.ShowAll
 		case key.Matches(msg, m.keys.quit):
+			m.cancel()
 			return m, tea.Quit
 		}
 	case tea.MouseMsg:
 		switch msg.Action {
 		case tea.MouseActionMotion:
 			if !(m.botTurn || m.gameOver) {
-				if row, col, found := m.findCellZone(msg); found {
+				if row, col, found := findCellZone(m.zone, m.zoneIds, msg); found {
 					m.cursorX = col
 					m.cursorY = row
 				}
 			}
 		case tea.MouseActionRelease:
 			if !(m.botTurn || m.gameOver) && msg.Button == tea.MouseButtonLeft {
-				if row, col, found := m.findCellZone(msg); found {
+				if row, col, found := findCellZone(m.zone, m.zoneIds, msg); found {
 					m.cursorX = col
 					m.cursorY = row
+					m.hint, m.hinting = nil, false
 					m.state, m.player, m.err = m.game.Play(tictactoe.Turn{
-						Cell: tictactoe.Cell{
-							Column: m.cursorX,
-							Row:    m.cursorY,
-						},
+						Cell:   m.focusCell(),
 						Player: m.player,
 					})
 					m.gameOver = m.state != tictactoe.StateAwaitingTurn
-					return m, m.allowBotTurn()
+					return m.allowBotTurn()
 				}
 			}
 		default:
 			// Do nothing
 		}
 	case tea.WindowSizeMsg:
+		m.height, m.width = msg.Height, msg.Width
 		m.help.Width = msg.Width
 	}
 	return m, nil
 }
 
 func (m model) View() string {
-	b := m.styles.board.Render(m.renderBoard())
+	board := m.renderBoard()
+	m.styles = m.styles.withMessageWidth(max(lipgloss.Width(board), minMessageWidth))
+	b := m.styles.board.Render(board)
 	var msg string
-	if m.forfeit {
-		msg = m.styles.messageForfeit.Render(m.renderPlayer() + " FORFEITS!")
+	if m.commanding {
+		prompt := "CELL: "
+		if m.game.HasGravity() {
+			prompt = "COLUMN: "
+		}
+		msg = m.styles.message.Render(prompt + strings.ToUpper(m.command) + "_")
+	} else if m.commandErr != nil {
+		msg = m.styles.messageError.Render(m.renderCommandErr())
+	} else if m.saveErr != nil {
+		msg = m.styles.messageError.Render("SAVE FAILED!")
+	} else if m.botErr != nil {
+		msg = m.styles.messageError.Render(m.renderPlayer() + " FAILED!")
+	} else if m.saved {
+		msg = m.styles.message.Render("SAVED TO " + m.savePath)
 	} else if m.gameOver {
 		switch m.state {
 		case tictactoe.StateDraw:
 			msg = m.styles.messageDraw.Render("DRAW!")
+		case tictactoe.StateForfeited:
+			msg = m.styles.messageForfeit.Render(m.renderPlayer() + " FORFEITS!")
 		case tictactoe.StateWon:
 			msg = m.styles.messageWin.Render(m.renderPlayer() + " WINS!")
 		default:
@@ -205,6 +367,8 @@ func (m model) View() string {
 		}
 	} else if m.botTurn {
 		msg = m.styles.message.Render(m.renderPlayer() + " THINKING...")
+	} else if m.hinting {
+		msg = m.styles.message.Render("ANALYZING...")
 	} else {
 		msg = m.styles.message.Render("READY " + m.renderPlayer())
 	}
@@ -212,101 +376,449 @@ func (m model) View() string {
 	return m.zone.Scan(lipgloss.JoinVertical(lipgloss.Top, b, msg, h))
 }
 
-func (m model) allowBotTurn() tea.Cmd {
-	if !m.game.IsBotTurn() {
-		return nil
-	}
-	return tea.Batch(startBotTurn(m.botTurnChan, m.game), awaitBotTurn(m.botTurnChan))
-}
-
-func (m model) findCellZone(msg tea.MouseMsg) (uint8, uint8, bool) {
-	for id := range m.zoneIds {
-		if m.zone.Get(id).InBounds(msg) {
-			if row, col, err := m.parseCellZoneId(id); err != nil {
-				panic(err)
-			} else {
-				return row, col, true
+// updateCommand handles the given key while a cell is being entered using its algebraic coordinates (e.g. "b2") or,
+// with gravity, a column is being entered using only its letters (e.g. "b")
+func (m model) updateCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.commanding = false
+		cell, err := m.parseCommand()
+		if err != nil {
+			m.commandErr = err
+			return m, nil
+		}
+		m.state, m.player, m.err = m.game.Play(tictactoe.Turn{
+			Cell:   cell,
+			Player: m.player,
+		})
+		if !errors.Is(m.err, tictactoe.ErrOutOfBounds) {
+			m.cursorX = cell.Column
+			m.cursorY = cell.Row
+		}
+		m.commandErr = m.err
+		m.gameOver = m.state != tictactoe.StateAwaitingTurn
+		return m.allowBotTurn()
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.commanding = false
+	case tea.KeyBackspace:
+		if l := len(m.command); l > 0 {
+			m.command = m.command[:l-1]
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if len(m.command) < maxCommandLength && (unicode.IsLetter(r) || unicode.IsDigit(r)) && r < unicode.MaxASCII {
+				m.command += string(r)
 			}
 		}
+	default:
+		// Do nothing
 	}
-	return 0, 0, false
+	return m, nil
 }
 
-func (m model) markCellZone(row, col int, value string) string {
-	id := fmt.Sprintf("cell:%d %d", col, row)
-	m.zoneIds[id] = struct{}{}
-	return m.zone.Mark(id, value)
+// allowBotTurn requests a turn from the bot for the current player, where applicable, which is flagged immediately so
+// that no key can modify the game until the bot has taken its turn
+func (m model) allowBotTurn() (model, tea.Cmd) {
+	if !m.game.IsBotTurn() {
+		return m, nil
+	}
+	m.botTurn = true
+	return m, tea.Batch(startBotTurn(m.ctx, m.botTurnChan, m.game, m.botDelay), awaitBotTurn(m.ctx, m.botTurnChan))
 }
 
-func (m model) parseCellZoneId(id string) (uint8, uint8, error) {
-	coords, found := strings.CutPrefix(id, "cell:")
-	if !found {
-		return 0, 0, fmt.Errorf("unexpected cell zone ID: %q", id)
+// focusCell returns the Cell in which a turn would be taken for the cursor, which, with gravity, is the lowest empty
+// Cell of the column under the cursor or the top Cell if the column is full
+func (m model) focusCell() tictactoe.Cell {
+	if m.game.HasGravity() {
+		if cell, ok := m.game.Board().FindLowestEmpty(m.cursorX); ok {
+			return cell
+		}
+		return tictactoe.Cell{Column: m.cursorX}
 	}
-
-	fields := strings.SplitN(coords, " ", 2)
-	if len(fields) != 2 {
-		return 0, 0, fmt.Errorf("malformed cell zone ID: %q", id)
+	return tictactoe.Cell{
+		Column: m.cursorX,
+		Row:    m.cursorY,
 	}
+}
 
-	col, err := strconv.ParseUint(fields[0], 10, 8)
+// parseCommand returns the Cell represented by the command, which, with gravity, can be only the letters of a column
+// (e.g. "b") for the lowest empty Cell of that column
+func (m model) parseCommand() (tictactoe.Cell, error) {
+	if !m.game.HasGravity() || strings.IndexFunc(m.command, unicode.IsDigit) >= 0 {
+		return tictactoe.ParseCell(m.command)
+	}
+	cell, err := tictactoe.ParseCell(m.command + "1")
 	if err != nil {
-		return 0, 0, fmt.Errorf("invalid column in cell zone ID: %q", id)
+		return cell, err
+	}
+	if lowest, ok := m.game.Board().FindLowestEmpty(cell.Column); ok {
+		return lowest, nil
+	}
+	// Column is either out-of-bounds or full, which is reported when played
+	return cell, nil
+}
+
+// layout returns the largest boardLayout that fits the terminal, falling back to a viewport containing only the rows
+// and columns surrounding the cursor if the board is too large to fit even using the smallest cellSize
+func (m model) layout() boardLayout {
+	rows, cols := int(m.game.Rows()), int(m.game.Columns())
+	l := boardLayout{
+		cellSize: cellSizes[0],
+		cols:     cols,
+		rows:     rows,
+	}
+	if m.height == 0 || m.width == 0 {
+		// Terminal size is not yet known
+		return l
 	}
 
-	row, err := strconv.ParseUint(fields[1], 10, 8)
-	if err != nil {
-		return 0, 0, fmt.Errorf("invalid row in cell zone ID: %q", id)
+	// Exclude margins of board and message as well as the message itself and help
+	width := m.width - m.styles.board.GetHorizontalMargins()
+	height := m.height - m.styles.board.GetVerticalMargins() - m.styles.message.GetVerticalFrameSize() -
+		m.styles.message.GetHeight() - lipgloss.Height(m.styles.help.Render(m.help.View(m.keys)))
+	for _, size := range cellSizes {
+		l.cellSize = size
+		if size.outerWidth()*cols <= width && size.outerHeight()*rows <= height {
+			return l
+		}
 	}
 
-	return uint8(row), uint8(col), nil
+	// Exclude rulers, with the row ruler being wide enough for the largest row number followed by a space
+	l.rulers = true
+	width -= len(strconv.Itoa(rows)) + 1
+	height--
+	l.cols = max(1, min(cols, width/l.outerWidth()))
+	l.rows = max(1, min(rows, height/l.outerHeight()))
+	// Keep cursor in the center of the viewport, where possible
+	focus := m.focusCell()
+	l.colStart = max(0, min(cols-l.cols, int(focus.Column)-l.cols/2))
+	l.rowStart = max(0, min(rows-l.rows, int(focus.Row)-l.rows/2))
+	return l
 }
 
 func (m model) renderBoard() string {
 	board := m.game.Board()
-	size := m.game.Size()
-	rows := make([]string, size)
-	for row, cols := range board {
-		cells := make([]string, size)
-		for col, player := range cols {
+	winningCells := m.game.WinningCells()
+	l := m.layout()
+	cell, cellAlt := l.apply(m.styles.cell), l.apply(m.styles.cellAlt)
+	cellColumn := l.apply(m.styles.cellColumn)
+	cellError, cellFocus := l.apply(m.styles.cellError), l.apply(m.styles.cellFocus)
+	focus := m.focusCell()
+	// With gravity, the column under the cursor is highlighted above the cell in which the turn would land
+	gravity := m.game.HasGravity() && !(m.botTurn || m.gameOver)
+	cellHint, cellWin := l.apply(m.styles.cellHint), l.apply(m.styles.cellWin)
+
+	rows := make([]string, l.rows)
+	for row := l.rowStart; row < l.rowStart+l.rows; row++ {
+		cells := make([]string, l.cols)
+		for col := l.colStart; col < l.colStart+l.cols; col++ {
 			var style lipgloss.Style
-			if row == int(m.cursorY) && col == int(m.cursorX) {
+			if slices.Contains(winningCells, tictactoe.Cell{Column: uint8(col), Row: uint8(row)}) {
+				style = cellWin
+			} else if row == int(focus.Row) && col == int(focus.Column) {
 				if m.err != nil {
-					style = m.styles.cellError
+					style = cellError
 				} else {
-					style = m.styles.cellFocus
+					style = cellFocus
 				}
+			} else if gravity && col == int(focus.Column) && row < int(focus.Row) {
+				style = cellColumn
+			} else if m.hint != nil && row == int(m.hint.Row) && col == int(m.hint.Column) {
+				style = cellHint
+			} else if !l.border && (row+col)%2 == 1 {
+				// Alternate backgrounds to distinguish between cells without borders
+				style = cellAlt
 			} else {
-				style = m.styles.cell
+				style = cell
 			}
-			cells[col] = m.markCellZone(row, col, style.Render(player.String()))
+			cells[col-l.colStart] = markCellZone(m.zone, m.zoneIds, row, col, style.Render(board[row][col].String()))
+		}
+		rows[row-l.rowStart] = lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+	}
+	b := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	if !l.rulers {
+		return b
+	}
+	return m.renderRulers(l, b)
+}
+
+func (m model) renderCommandErr() string {
+	command := strings.ToUpper(m.command)
+	switch {
+	case errors.Is(m.commandErr, tictactoe.ErrNotationInvalid):
+		return "INVALID CELL: " + command
+	case errors.Is(m.commandErr, tictactoe.ErrOutOfBounds):
+		return "OUT OF BOUNDS: " + command
+	case errors.Is(m.commandErr, tictactoe.ErrTurnInvalid) && m.game.HasGravity():
+		if _, ok := m.game.Board().FindLowestEmpty(m.cursorX); !ok {
+			return "COLUMN FULL: " + command
+		}
+		return "NOT LOWEST CELL: " + command
+	case errors.Is(m.commandErr, tictactoe.ErrTurnInvalid):
+		return "CELL TAKEN: " + command
+	default:
+		return "FAILED: " + command
+	}
+}
+
+// renderRulers returns the given rendered board surrounded by a ruler above, containing column letters, and a ruler to
+// the left, containing row numbers, so that the cells within the viewport can be identified
+func (m model) renderRulers(l boardLayout, board string) string {
+	rowRulerWidth := len(strconv.Itoa(int(m.game.Rows())))
+	rowLabels := make([]string, l.rows)
+	rowLabel := m.styles.ruler.
+		Width(rowRulerWidth).
+		Height(l.outerHeight()).
+		Align(lipgloss.Right, lipgloss.Center).
+		MarginRight(1)
+	for row := l.rowStart; row < l.rowStart+l.rows; row++ {
+		rowLabels[row-l.rowStart] = rowLabel.Render(strconv.Itoa(row + 1))
+	}
+
+	// Labels are centered above each column, where possible, but skipped whenever they would overlap the previous label
+	colRuler := []rune(strings.Repeat(" ", l.cols*l.outerWidth()))
+	next := 0
+	for col := l.colStart; col < l.colStart+l.cols; col++ {
+		label := strings.TrimRight(tictactoe.FormatCell(tictactoe.Cell{Column: uint8(col)}), "1")
+		x := (col-l.colStart)*l.outerWidth() + max(0, (l.outerWidth()-len(label))/2)
+		if x >= next && x+len(label) <= len(colRuler) {
+			copy(colRuler[x:], []rune(label))
+			next = x + len(label) + 1
 		}
-		rows[row] = lipgloss.JoinHorizontal(lipgloss.Top, cells...)
 	}
-	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		strings.Repeat(" ", rowRulerWidth+1)+m.styles.ruler.Render(string(colRuler)),
+		lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.JoinVertical(lipgloss.Right, rowLabels...), board),
+	)
 }
 
 func (m model) renderPlayer() string {
-	switch m.player {
-	case tictactoe.PlayerOne:
-		return "PLAYER ONE"
-	case tictactoe.PlayerTwo:
-		return "PLAYER TWO"
+	return renderPlayer(m.player)
+}
+
+func initModel(pack tictactoe.Pack, seeder *seeder, savePath string, botDelay time.Duration, zm *zone.Manager,
+	opts ...tictactoe.Option) model {
+	// Bot turns and hints are taken in other goroutines while the board is rendered so game must be safe for concurrent
+	// use. Each game has its own seed so that the same random decisions are not made when game is restarted.
+	g := tictactoe.MustStart(append([]tictactoe.Option{tictactoe.WithConcurrencySafety(),
+		tictactoe.WithSeed(seeder.next()), tictactoe.WithPack(pack)}, opts...)...)
+	p, s := g.Player(), g.State()
+
+	km := newKeyMap()
+	st := newStyles()
+
+	if g.HasGravity() {
+		// Turns are taken by choosing a column, with the command also accepting a column
+		km.command.SetHelp(":", "enter column (e.g. b)")
+		km.down.SetEnabled(false)
+		km.up.SetEnabled(false)
+	}
+
+	h := help.New()
+	h.Styles.FullKey.Bold(true)
+	h.Styles.ShortKey.Bold(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return model{
+		botDelay:    botDelay,
+		botTurn:     g.IsBotTurn(),
+		botTurnChan: make(chan botTurnMsg),
+		cancel:      cancel,
+		ctx:         ctx,
+		game:        g,
+		gameOver:    s != tictactoe.StateAwaitingTurn,
+		help:        h,
+		keys:        km,
+		pack:        pack,
+		player:      p,
+		savePath:    savePath,
+		seeder:      seeder,
+		state:       s,
+		styles:      st,
+		zone:        zm,
+		zoneIds:     make(map[string]struct{}),
+	}
+}
+
+// minMessageWidth is the minimum width of the message below the board so that it's readable even when the board is
+// rendered using the smallest cellSize
+const minMessageWidth = 24
+
+// maxCommandLength is the maximum length of algebraic coordinates for a cell on the largest board (e.g. "iu255")
+const maxCommandLength = 5
+
+const (
+	flagNameBot          = "bot"
+	flagNameBot1         = "bot1"
+	flagNameBot2         = "bot2"
+	flagNameBotDelay     = "bot-delay"
+	flagNameColumns      = "columns"
+	flagNameGravity      = "gravity"
+	flagNameHeadless     = "headless"
+	flagNameHelp         = "help"
+	flagNameLoad         = "load"
+	flagNameMCTSPlayouts = "mcts-playouts"
+	flagNameMCTSTime     = "mcts-time"
+	flagNameMisere       = "misere"
+	flagNameNoMouse      = "no-mouse"
+	flagNamePlayer       = "player"
+	flagNamePosition     = "position"
+	flagNameProfile      = "profile"
+	flagNameQubic        = "qubic"
+	flagNameRatings      = "ratings"
+	flagNameRows         = "rows"
+	flagNameSeed         = "seed"
+	flagNameSize         = "size"
+	flagNameStats        = "stats"
+	flagNameUltimate     = "ultimate"
+	flagNameWinLength    = "win-length"
+
+	flagInvalidReasonBotMaxSizeExceeded = "bot max board size exceeded"
+	flagInvalidReasonBotsRequired       = "bot required for both players"
+	flagInvalidReasonConflict           = "cannot be combined with -" + flagNameUltimate
+	flagInvalidReasonGame               = "invalid game"
+	flagInvalidReasonOutOfRange         = "value out of range"
+	flagInvalidReasonParse              = "parse error"
+	flagInvalidReasonRead               = "read error"
+
+	defaultRatingsPath = "tic-tac-toe-ratings.json"
+	defaultSavePath    = "tic-tac-toe.json"
+)
+
+func analyzeGame(ctx context.Context, game tictactoe.Game) tea.Cmd {
+	turns := len(game.Turns())
+	return func() tea.Msg {
+		analyses, err := tictactoe.AnalyzeContext(ctx, game)
+		return hintMsg{
+			analyses: analyses,
+			err:      err,
+			game:     game,
+			turns:    turns,
+		}
+	}
+}
+
+func awaitBotTurn(ctx context.Context, ch chan botTurnMsg) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-ch:
+			return msg
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func botOption(name string, player tictactoe.Player, mctsBudget tictactoe.MCTSBudget) (tictactoe.Option, uint8, bool) {
+	switch name {
+	case bot.NameEasy:
+		return tictactoe.WithEasyBot(player), bot.MaxSizeEasy, true
+	case bot.NameNormal:
+		return tictactoe.WithNormalBot(player), bot.MaxSizeNormal, true
+	case bot.NameHard:
+		return tictactoe.WithHardBot(player), bot.MaxSizeHard, true
+	case bot.NameImpossible:
+		return tictactoe.WithImpossibleBot(player), bot.MaxSizeImpossible, true
+	case bot.NameMCTS:
+		return tictactoe.WithMCTSBot(player, mctsBudget), bot.MaxSizeMCTS, true
 	default:
-		// Should never happen
-		return "PLAYER UNKNOWN"
+		return nil, 0, false
 	}
 }
 
-func initModel(pack tictactoe.Pack, zm *zone.Manager) model {
-	g := tictactoe.MustStart(tictactoe.WithPack(pack))
-	p, s := g.Player(), g.State()
+// findCellZone returns the row and column of the cell whose zone contains the given mouse event, where found
+func findCellZone(zm *zone.Manager, ids map[string]struct{}, msg tea.MouseMsg) (uint8, uint8, bool) {
+	for id := range ids {
+		if zm.Get(id).InBounds(msg) {
+			if row, col, err := parseCellZoneId(id); err != nil {
+				panic(err)
+			} else {
+				return row, col, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func handleInvalidFlag(name string, value any, reason string) {
+	fmt.Printf(`invalid value "%v" for flag -%s: %s
+`, value, name, reason)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func loadGame(path string, mctsBudget tictactoe.MCTSBudget) (tictactoe.Pack, tictactoe.Option) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		handleInvalidFlag(flagNameLoad, path, fmt.Sprintf("%s (%v)", flagInvalidReasonRead, err))
+	}
+
+	var snapshot tictactoe.Snapshot
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		handleInvalidFlag(flagNameLoad, path, fmt.Sprintf("%s (%v)", flagInvalidReasonParse, err))
+	}
+
+	// Restarting a loaded game starts a new game using the same settings
+	pack := tictactoe.Pack{
+		tictactoe.WithDimensions(snapshot.Rows, snapshot.Columns),
+		tictactoe.WithWinLength(snapshot.WinLength),
+	}
+	if snapshot.Starter.IsValid() {
+		pack = append(pack, tictactoe.WithStarterPlayer(snapshot.Starter))
+	}
+	if snapshot.Gravity {
+		pack = append(pack, tictactoe.WithGravity())
+	}
+	if snapshot.Misere {
+		pack = append(pack, tictactoe.WithMisere())
+	}
+	// Any built-in bot is created using the flags so that they're applied to it, otherwise it's created by the snapshot
+	var botOpts tictactoe.Pack
+	bots := snapshot.Bots
+	if snapshot.Bot != nil {
+		// Bot is only populated for snapshots saved before bots could play each other
+		bots = append(bots, *snapshot.Bot)
+	}
+	for _, b := range bots {
+		if opt, _, ok := botOption(b.Name, b.Player, mctsBudget); ok {
+			botOpts = append(botOpts, opt)
+		}
+	}
+
+	restore := tictactoe.WithSnapshot(snapshot)
+	g, err := tictactoe.Start(tictactoe.WithPack(pack), tictactoe.WithPack(botOpts), restore)
+	if err != nil {
+		handleInvalidFlag(flagNameLoad, path, fmt.Sprintf("%s (%v)", flagInvalidReasonGame, err))
+	}
+	// Restarting with the bots of the loaded game ensures that each is played by the same bot, configured the same way
+	for _, player := range tictactoe.Players() {
+		if b := g.Bot(player); b != nil {
+			pack = append(pack, tictactoe.WithBot(b))
+		}
+	}
+	return pack, restore
+}
+
+// markCellZone marks the given rendered value as the zone of the cell at the given row and column
+func markCellZone(zm *zone.Manager, ids map[string]struct{}, row, col int, value string) string {
+	id := fmt.Sprintf("cell:%d %d", col, row)
+	ids[id] = struct{}{}
+	return zm.Mark(id, value)
+}
 
-	km := keyMap{
+// newKeyMap returns the key bindings shared by every game, where any not applicable to a game are disabled by it
+func newKeyMap() keyMap {
+	return keyMap{
 		choose: key.NewBinding(
 			key.WithKeys(" "),
 			key.WithHelp("space", "select"),
 		),
+		command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "enter cell (e.g. b2)"),
+		),
 		down: key.NewBinding(
 			key.WithKeys("down", "s"),
 			key.WithHelp("↓/s", "move down"),
@@ -315,6 +827,10 @@ func initModel(pack tictactoe.Pack, zm *zone.Manager) model {
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
 		),
+		hint: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "hint"),
+		),
 		left: key.NewBinding(
 			key.WithKeys("left", "a"),
 			key.WithHelp("←/a", "move left"),
@@ -323,6 +839,14 @@ func initModel(pack tictactoe.Pack, zm *zone.Manager) model {
 			key.WithKeys("q", "esc", "ctrl+c"),
 			key.WithHelp("q", "quit"),
 		),
+		redo: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "redo"),
+		),
+		resign: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "forfeit"),
+		),
 		restart: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "restart"),
@@ -331,23 +855,32 @@ func initModel(pack tictactoe.Pack, zm *zone.Manager) model {
 			key.WithKeys("right", "d"),
 			key.WithHelp("→/d", "move right"),
 		),
+		undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo"),
+		),
+		save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "save"),
+		),
 		up: key.NewBinding(
 			key.WithKeys("up", "w"),
 			key.WithHelp("↑/w", "move up"),
 		),
 	}
+}
 
+// newStyles returns the styles shared by every game
+func newStyles() styles {
+	// Size of each cell is applied when rendering the board
 	cst := lipgloss.NewStyle().
-		Width(15).
-		Height(5).
 		Align(lipgloss.Center, lipgloss.Center).
 		Bold(true).
 		Background(lipgloss.ANSIColor(15)).
 		Foreground(lipgloss.ANSIColor(0)).
-		Border(lipgloss.OuterHalfBlockBorder(), true, true, true, true).
 		BorderForeground(lipgloss.ANSIColor(15))
+	// Width of message is applied when rendering to match that of the board
 	mst := lipgloss.NewStyle().
-		Width((cst.GetHorizontalFrameSize()+cst.GetWidth())*int(g.Size())).
 		Height(1).
 		Margin(0, 1, 1, 1).
 		Align(lipgloss.Center, lipgloss.Center).
@@ -355,100 +888,170 @@ func initModel(pack tictactoe.Pack, zm *zone.Manager) model {
 		Background(lipgloss.ANSIColor(15)).
 		Foreground(lipgloss.ANSIColor(0))
 
-	st := styles{
+	return styles{
 		board: lipgloss.NewStyle().
 			Margin(1, 1, 0, 1),
 		cell: cst,
+		cellAlt: cst.
+			Background(lipgloss.ANSIColor(7)),
+		cellColumn: cst.
+			Background(lipgloss.ANSIColor(153)).
+			Foreground(lipgloss.ANSIColor(4)),
 		cellError: cst.
 			Background(lipgloss.ANSIColor(9)).
 			Foreground(lipgloss.ANSIColor(1)),
 		cellFocus: cst.
 			Background(lipgloss.ANSIColor(33)).
 			Foreground(lipgloss.ANSIColor(4)),
+		cellHint: cst.
+			Background(lipgloss.ANSIColor(11)).
+			Foreground(lipgloss.ANSIColor(3)),
+		cellWin: cst.
+			Background(lipgloss.ANSIColor(10)).
+			Foreground(lipgloss.ANSIColor(22)),
 		help: lipgloss.NewStyle().
 			Margin(0, 1),
 		message: mst,
 		messageDraw: mst.
 			Background(lipgloss.ANSIColor(3)).
 			Foreground(lipgloss.ANSIColor(11)),
+		messageError: mst.
+			Background(lipgloss.ANSIColor(9)).
+			Foreground(lipgloss.ANSIColor(1)),
 		messageForfeit: mst.
 			Background(lipgloss.ANSIColor(9)).
 			Foreground(lipgloss.ANSIColor(1)),
 		messageWin: mst.
 			Background(lipgloss.ANSIColor(10)).
 			Foreground(lipgloss.ANSIColor(22)),
+		ruler: lipgloss.NewStyle().
+			Faint(true),
+	}
+}
+
+func parseCellZoneId(id string) (uint8, uint8, error) {
+	coords, found := strings.CutPrefix(id, "cell:")
+	if !found {
+		return 0, 0, fmt.Errorf("unexpected cell zone ID: %q", id)
 	}
 
-	h := help.New()
-	h.Styles.FullKey.Bold(true)
-	h.Styles.ShortKey.Bold(true)
+	fields := strings.SplitN(coords, " ", 2)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("malformed cell zone ID: %q", id)
+	}
 
-	return model{
-		botTurnChan: make(chan botTurnMsg),
-		game:        g,
-		help:        h,
-		keys:        km,
-		pack:        pack,
-		player:      p,
-		state:       s,
-		styles:      st,
-		zone:        zm,
-		zoneIds:     make(map[string]struct{}),
+	col, err := strconv.ParseUint(fields[0], 10, 8)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid column in cell zone ID: %q", id)
 	}
-}
 
-const (
-	flagNameBot     = "bot"
-	flagNameHelp    = "help"
-	flagNameNoMouse = "no-mouse"
-	flagNamePlayer  = "player"
-	flagNameSize    = "size"
+	row, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid row in cell zone ID: %q", id)
+	}
 
-	flagInvalidReasonBotMaxSizeExceeded = "bot max board size exceeded"
-	flagInvalidReasonOutOfRange         = "value out of range"
-	flagInvalidReasonParse              = "parse error"
-)
+	return uint8(row), uint8(col), nil
+}
 
-func awaitBotTurn(ch chan botTurnMsg) tea.Cmd {
-	return func() tea.Msg {
-		return <-ch
+// playHeadless plays the given Game, where both players are bots, until it's over while printing the board after each
+// turn followed by the record of the game
+func playHeadless(game tictactoe.Game, delay time.Duration) {
+	fmt.Printf("%s\n\n", game.Board())
+	for game.IsBotTurn() {
+		time.Sleep(delay)
+		player := game.Player()
+		if _, _, err := game.AllowBotTurn(); err != nil {
+			// Built-in bots should never cause errors to return
+			panic(err)
+		}
+		turn, _ := game.LastTurn()
+		fmt.Printf("%s %s\n%s\n\n", player, tictactoe.FormatCell(turn.Cell), game.Board())
 	}
+	fmt.Print(tictactoe.NewRecord(game))
 }
 
-func handleInvalidFlag(name string, value any, reason string) {
-	fmt.Printf(`invalid value "%v" for flag -%s: %s
-`, value, name, reason)
-	flag.Usage()
-	os.Exit(2)
+func renderPlayer(player tictactoe.Player) string {
+	switch player {
+	case tictactoe.PlayerOne:
+		return "PLAYER ONE"
+	case tictactoe.PlayerTwo:
+		return "PLAYER TWO"
+	default:
+		// Should never happen
+		return "PLAYER UNKNOWN"
+	}
+}
+
+func saveGame(path string, game tictactoe.Game) error {
+	data, err := game.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
 }
 
-func startBotTurn(ch chan botTurnMsg, game tictactoe.Game) tea.Cmd {
+func startBotTurn(ctx context.Context, ch chan botTurnMsg, game tictactoe.Game, delay time.Duration) tea.Cmd {
 	return func() tea.Msg {
 		go func() {
-			state, player, err := game.AllowBotTurn()
-			ch <- botTurnMsg{
+			// Delay allows turns to be followed when watching bots play each other
+			if delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return
+				}
+			}
+			state, player, err := game.AllowBotTurnContext(ctx)
+			select {
+			case ch <- botTurnMsg{
 				err:    err,
+				game:   game,
 				player: player,
 				state:  state,
+			}:
+			case <-ctx.Done():
+				// Game has been restarted or quit so nothing is awaiting the bot turn
 			}
 		}()
 
-		return botTurnStartedMsg{}
+		return nil
 	}
 }
 
 func main() {
 	var (
-		botFlag               string
-		helpFlag, noMouseFlag bool
-		playerFlag, sizeFlag  uint
+		botFlag, bot1Flag, bot2Flag, loadFlag, positionFlag, profileFlag, ratingsFlag string
+		gravityFlag, headlessFlag, helpFlag, misereFlag, noMouseFlag, statsFlag       bool
+		qubicFlag, ultimateFlag                                                       bool
+		columnsFlag, mctsPlayoutsFlag, playerFlag, rowsFlag, sizeFlag, winLengthFlag  uint
+		botDelayFlag, mctsTimeFlag                                                    time.Duration
+		seedFlag                                                                      int64
 	)
 
-	flag.StringVar(&botFlag, flagNameBot, "", `enable bot opponent with difficulty (e.g. "normal")`)
+	flag.StringVar(&botFlag, flagNameBot, "", `enable bot opponent with difficulty (e.g. "normal"), same as -bot2`)
+	flag.StringVar(&bot1Flag, flagNameBot1, "", `enable bot for player 1 with difficulty (e.g. "normal")`)
+	flag.StringVar(&bot2Flag, flagNameBot2, "", `enable bot for player 2 with difficulty (e.g. "normal")`)
+	flag.DurationVar(&botDelayFlag, flagNameBotDelay, 0, "delay before each bot turn (e.g. 500ms)")
+	flag.UintVar(&columnsFlag, flagNameColumns, 0, "number of columns on board (default size of board)")
+	flag.BoolVar(&gravityFlag, flagNameGravity, false, "drop each turn to the lowest empty cell of its column")
+	flag.BoolVar(&headlessFlag, flagNameHeadless, false, "play bots against each other without UI, printing each turn")
 	flag.BoolVar(&helpFlag, flagNameHelp, false, "print help")
+	flag.StringVar(&loadFlag, flagNameLoad, "", "load saved game from file, ignoring other game flags")
+	flag.UintVar(&mctsPlayoutsFlag, flagNameMCTSPlayouts, 0, `maximum playouts per turn for "mcts" bot (default 10000)`)
+	flag.DurationVar(&mctsTimeFlag, flagNameMCTSTime, 0, `maximum time per turn for "mcts" bot (default 1s)`)
+	flag.BoolVar(&misereFlag, flagNameMisere, false, "play misère variant where completing a line loses")
 	flag.BoolVar(&noMouseFlag, flagNameNoMouse, false, "disable mouse support")
 	flag.UintVar(&playerFlag, flagNamePlayer, 1, "starter player")
+	flag.StringVar(&positionFlag, flagNamePosition, "", `start from position in compact notation (e.g. "xo./.x./..o x")`)
+	flag.StringVar(&profileFlag, flagNameProfile, "", "local profile rating games against bots, enabling ratings")
+	flag.BoolVar(&qubicFlag, flagNameQubic, false, "play 3D tic-tac-toe on a 4x4x4 board, with its layers side by side")
+	flag.StringVar(&ratingsFlag, flagNameRatings, defaultRatingsPath, "file storing ratings, enabling ratings")
+	flag.UintVar(&rowsFlag, flagNameRows, 0, "number of rows on board (default size of board)")
+	flag.Int64Var(&seedFlag, flagNameSeed, 0, "seed for randomness, printed on exit to replay session (default random)")
 	flag.UintVar(&sizeFlag, flagNameSize, 3, "size of board")
+	flag.BoolVar(&statsFlag, flagNameStats, false, "print leaderboard of ratings for each variant")
+	flag.BoolVar(&ultimateFlag, flagNameUltimate, false, "play ultimate tic-tac-toe, where each cell holds a board")
+	flag.UintVar(&winLengthFlag, flagNameWinLength, 0, "number of cells in a row required to win (default size of board)")
 	flag.Parse()
 
 	if helpFlag {
@@ -456,6 +1059,30 @@ func main() {
 		return
 	}
 
+	// Ratings are only enabled where their file or a profile is given so that no file is written otherwise
+	var ratingsEnabled, seedSet bool
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case flagNameProfile, flagNameRatings:
+			ratingsEnabled = true
+		case flagNameSeed:
+			seedSet = true
+		}
+	})
+	var r *ratings.Ratings
+	if ratingsEnabled || statsFlag {
+		var err error
+		if r, err = ratings.Load(ratingsFlag); err != nil {
+			handleInvalidFlag(flagNameRatings, ratingsFlag, fmt.Sprintf("%s (%v)", flagInvalidReasonParse, err))
+		}
+	}
+	if statsFlag {
+		if err := printStats(os.Stdout, r, profileFlag); err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	var player tictactoe.Player
 	if playerFlag == 0 || playerFlag > 2 {
 		handleInvalidFlag(flagNamePlayer, playerFlag, flagInvalidReasonOutOfRange)
@@ -463,6 +1090,32 @@ func main() {
 		player = tictactoe.Player(playerFlag)
 	}
 
+	bot2FlagName := flagNameBot2
+	if bot2Flag == "" {
+		bot2Flag, bot2FlagName = botFlag, flagNameBot
+	}
+	seed := seedFlag
+	if !seedSet {
+		seed = rand.Int63()
+	}
+	seeder := newSeeder(seed)
+
+	if qubicFlag {
+		if ultimateFlag {
+			handleInvalidFlag(flagNameQubic, qubicFlag, flagInvalidReasonConflict)
+		}
+		opts := qubicOptions(bot1Flag, bot2Flag, bot2FlagName, player)
+		playQubic(opts, seeder, botDelayFlag, headlessFlag, noMouseFlag)
+		seeder.print()
+		return
+	}
+	if ultimateFlag {
+		opts := ultimateOptions(bot1Flag, bot2Flag, bot2FlagName, player)
+		playUltimate(opts, seeder, botDelayFlag, headlessFlag, noMouseFlag)
+		seeder.print()
+		return
+	}
+
 	var size uint8
 	if sizeFlag < uint(tictactoe.MinSize) || sizeFlag > uint(tictactoe.MaxSize) {
 		handleInvalidFlag(flagNameSize, sizeFlag, flagInvalidReasonOutOfRange)
@@ -470,29 +1123,128 @@ func main() {
 		size = uint8(sizeFlag)
 	}
 
-	var maxSize uint8
-	pack := tictactoe.Pack{tictactoe.WithSize(size), tictactoe.WithStarterPlayer(player)}
-	switch botFlag {
-	case "":
-		// Do nothing
-	case bot.NameEasy:
-		pack = append(pack, tictactoe.WithEasyBot(tictactoe.PlayerTwo))
-		maxSize = bot.MaxSizeEasy
-	case bot.NameNormal:
-		pack = append(pack, tictactoe.WithNormalBot(tictactoe.PlayerTwo))
-		maxSize = bot.MaxSizeNormal
-	case bot.NameHard:
-		pack = append(pack, tictactoe.WithHardBot(tictactoe.PlayerTwo))
-		maxSize = bot.MaxSizeHard
-	case bot.NameImpossible:
-		pack = append(pack, tictactoe.WithImpossibleBot(tictactoe.PlayerTwo))
-		maxSize = bot.MaxSizeImpossible
-	default:
-		handleInvalidFlag(flagNameBot, botFlag, flagInvalidReasonParse)
+	rows, cols := size, size
+	if rowsFlag > 0 {
+		if rowsFlag < uint(tictactoe.MinSize) || rowsFlag > uint(tictactoe.MaxSize) {
+			handleInvalidFlag(flagNameRows, rowsFlag, flagInvalidReasonOutOfRange)
+		} else {
+			rows = uint8(rowsFlag)
+		}
+	}
+	if columnsFlag > 0 {
+		if columnsFlag < uint(tictactoe.MinSize) || columnsFlag > uint(tictactoe.MaxSize) {
+			handleInvalidFlag(flagNameColumns, columnsFlag, flagInvalidReasonOutOfRange)
+		} else {
+			cols = uint8(columnsFlag)
+		}
+	}
+
+	var variantOpts []tictactoe.Option
+	if gravityFlag {
+		variantOpts = append(variantOpts, tictactoe.WithGravity())
+	}
+	if misereFlag {
+		variantOpts = append(variantOpts, tictactoe.WithMisere())
+	}
+
+	if positionFlag != "" {
+		// Variants are passed so that a position that's impossible with gravity is rejected
+		if g, err := tictactoe.ParseGame(positionFlag, variantOpts...); err != nil {
+			handleInvalidFlag(flagNamePosition, positionFlag, fmt.Sprintf("%s (%v)", flagInvalidReasonParse, err))
+		} else {
+			rows, cols = g.Rows(), g.Columns()
+		}
+	}
+
+	var winLength uint8
+	if winLengthFlag > 0 {
+		if winLengthFlag < uint(tictactoe.MinWinLength) || winLengthFlag > uint(max(rows, cols)) {
+			handleInvalidFlag(flagNameWinLength, winLengthFlag, flagInvalidReasonOutOfRange)
+		} else {
+			winLength = uint8(winLengthFlag)
+		}
+	}
+
+	mctsBudget := tictactoe.MCTSBudget{
+		Duration: mctsTimeFlag,
+		Playouts: int(mctsPlayoutsFlag),
+	}
+
+	pack := tictactoe.Pack{tictactoe.WithDimensions(rows, cols), tictactoe.WithStarterPlayer(player)}
+	if positionFlag != "" {
+		pack = append(pack, tictactoe.WithPosition(positionFlag))
+	}
+	if winLength > 0 {
+		pack = append(pack, tictactoe.WithWinLength(winLength))
+	}
+	pack = append(pack, variantOpts...)
+
+	var (
+		maxSize     uint8
+		maxSizeName string
+	)
+	for _, b := range []struct {
+		flagName, name string
+		player         tictactoe.Player
+	}{
+		{flagNameBot1, bot1Flag, tictactoe.PlayerOne},
+		{bot2FlagName, bot2Flag, tictactoe.PlayerTwo},
+	} {
+		if b.name == "" {
+			continue
+		}
+		opt, botMaxSize, ok := botOption(b.name, b.player, mctsBudget)
+		if !ok {
+			handleInvalidFlag(b.flagName, b.name, flagInvalidReasonParse)
+		}
+		pack = append(pack, opt)
+		if maxSize == 0 || botMaxSize < maxSize {
+			maxSize, maxSizeName = botMaxSize, b.name
+		}
+	}
+
+	if maxSize > 0 && max(rows, cols) > maxSize {
+		reason := fmt.Sprintf("%q %s (%v)", maxSizeName, flagInvalidReasonBotMaxSizeExceeded, maxSize)
+		switch {
+		case positionFlag != "":
+			handleInvalidFlag(flagNamePosition, positionFlag, reason)
+		case rowsFlag > 0 && rows > maxSize:
+			handleInvalidFlag(flagNameRows, rowsFlag, reason)
+		case columnsFlag > 0 && cols > maxSize:
+			handleInvalidFlag(flagNameColumns, columnsFlag, reason)
+		default:
+			handleInvalidFlag(flagNameSize, sizeFlag, reason)
+		}
+	}
+
+	savePath := defaultSavePath
+	var gameOpts []tictactoe.Option
+	if loadFlag != "" {
+		var restore tictactoe.Option
+		pack, restore = loadGame(loadFlag, mctsBudget)
+		gameOpts = append(gameOpts, restore)
+		savePath = loadFlag
+	}
+
+	// Observer is added to pack so that every game is rated, including those started on restart
+	var ratingsObs *ratingsObserver
+	if ratingsEnabled {
+		ratingsObs = newRatingsObserver(r, ratingsFlag, profileFlag)
+		pack = append(pack, tictactoe.WithObserver(ratingsObs))
 	}
 
-	if botFlag != "" && maxSize > 0 && size > maxSize {
-		handleInvalidFlag(flagNameSize, sizeFlag, fmt.Sprintf("%q %s (%v)", botFlag, flagInvalidReasonBotMaxSizeExceeded, maxSize))
+	if headlessFlag {
+		g := tictactoe.MustStart(append([]tictactoe.Option{tictactoe.WithSeed(seeder.next()), tictactoe.WithPack(pack)},
+			gameOpts...)...)
+		if g.Bot(tictactoe.PlayerOne) == nil || g.Bot(tictactoe.PlayerTwo) == nil {
+			handleInvalidFlag(flagNameHeadless, headlessFlag, flagInvalidReasonBotsRequired)
+		}
+		playHeadless(g, botDelayFlag)
+		if ratingsObs != nil {
+			ratingsObs.save()
+		}
+		seeder.print()
+		return
 	}
 
 	zm := zone.New()
@@ -504,8 +1256,13 @@ func main() {
 		opts = append(opts, tea.WithMouseAllMotion())
 	}
 
-	p := tea.NewProgram(initModel(pack, zm), opts...)
+	p := tea.NewProgram(initModel(pack, seeder, savePath, botDelayFlag, zm, gameOpts...), opts...)
 	if _, err := p.Run(); err != nil {
 		panic(err)
 	}
+
+	if ratingsObs != nil {
+		ratingsObs.save()
+	}
+	seeder.print()
 }
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/qubic"
+	"slices"
+	"strings"
+	"time"
+)
+
+// qubicColumns is the number of columns across all layers, which are rendered side by side
+const qubicColumns = qubic.Size * qubic.Size
+
+type qubicBotTurnMsg struct {
+	err    error
+	game   qubic.Game
+	player tictactoe.Player
+	state  tictactoe.State
+}
+
+type qubicModel struct {
+	board            qubic.Board
+	botDelay         time.Duration
+	botErr           error
+	botTurn          bool
+	cancel           context.CancelFunc
+	ctx              context.Context
+	cursorX, cursorY uint8
+	err              error
+	game             qubic.Game
+	gameOver         bool
+	height, width    int
+	help             help.Model
+	keys             keyMap
+	opts             []qubic.Option
+	player           tictactoe.Player
+	seeder           *seeder
+	state            tictactoe.State
+	styles           styles
+	winningCells     qubic.Cells
+	zone             *zone.Manager
+	zoneIds          map[string]struct{}
+}
+
+func (m qubicModel) Init() tea.Cmd {
+	return tea.Batch(tea.SetWindowTitle("tic-tac-toe (qubic)"), m.startBotTurn())
+}
+
+func (m qubicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case qubicBotTurnMsg:
+		// Ignore any bot turn for a previous game (e.g. before restart)
+		if msg.game == m.game && !m.gameOver {
+			if msg.err != nil {
+				// Bot is not asked to take its turn again if it failed, otherwise it would likely fail again and again
+				m.botErr = msg.err
+				m.botTurn = false
+				return m, nil
+			}
+			return m.played(msg.state, msg.player, nil)
+		}
+	case tea.KeyMsg:
+		m.botErr = nil
+		switch {
+		case key.Matches(msg, m.keys.choose):
+			if !(m.botTurn || m.gameOver) {
+				return m.play()
+			}
+		case key.Matches(msg, m.keys.up):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorY = (m.cursorY + qubic.Size - 1) % qubic.Size
+			}
+		case key.Matches(msg, m.keys.down):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorY = (m.cursorY + 1) % qubic.Size
+			}
+		case key.Matches(msg, m.keys.left):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorX = (m.cursorX + qubicColumns - 1) % qubicColumns
+			}
+		case key.Matches(msg, m.keys.right):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorX = (m.cursorX + 1) % qubicColumns
+			}
+		case key.Matches(msg, m.keys.resign):
+			if !(m.botTurn || m.gameOver) {
+				state, player, err := m.game.Resign(m.player)
+				return m.played(state, player, err)
+			}
+		case key.Matches(msg, m.keys.restart):
+			m.cancel()
+			nm := initQubicModel(m.opts, m.seeder, m.botDelay, m.zone)
+			nm.height, nm.width = m.height, m.width
+			nm.help.Width = m.help.Width
+			return nm, nm.startBotTurn()
+		case key.Matches(msg, m.keys.help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, m.keys.quit):
+			m.cancel()
+			return m, tea.Quit
+		}
+	case tea.MouseMsg:
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			if !(m.botTurn || m.gameOver) {
+				if row, col, found := findCellZone(m.zone, m.zoneIds, msg); found {
+					m.cursorX = col
+					m.cursorY = row
+				}
+			}
+		case tea.MouseActionRelease:
+			if !(m.botTurn || m.gameOver) && msg.Button == tea.MouseButtonLeft {
+				if row, col, found := findCellZone(m.zone, m.zoneIds, msg); found {
+					m.cursorX = col
+					m.cursorY = row
+					return m.play()
+				}
+			}
+		default:
+			// Do nothing
+		}
+	case tea.WindowSizeMsg:
+		m.height, m.width = msg.Height, msg.Width
+		m.help.Width = msg.Width
+	}
+	return m, nil
+}
+
+func (m qubicModel) View() string {
+	board := m.renderBoard()
+	m.styles = m.styles.withMessageWidth(max(lipgloss.Width(board), minMessageWidth))
+	b := m.styles.board.Render(board)
+	var msg string
+	if m.botErr != nil {
+		msg = m.styles.messageError.Render(renderPlayer(m.player) + " FAILED!")
+	} else if m.gameOver {
+		switch m.state {
+		case tictactoe.StateDraw:
+			msg = m.styles.messageDraw.Render("DRAW!")
+		case tictactoe.StateForfeited:
+			msg = m.styles.messageForfeit.Render(renderPlayer(m.player) + " FORFEITS!")
+		case tictactoe.StateWon:
+			msg = m.styles.messageWin.Render(renderPlayer(m.player) + " WINS!")
+		default:
+			panic(fmt.Errorf("unexpected final game state: %v", m.state))
+		}
+	} else if m.botTurn {
+		msg = m.styles.message.Render(renderPlayer(m.player) + " THINKING...")
+	} else {
+		msg = m.styles.message.Render("READY " + renderPlayer(m.player))
+	}
+	h := m.styles.help.Render(m.help.View(m.keys))
+	return m.zone.Scan(lipgloss.JoinVertical(lipgloss.Top, b, msg, h))
+}
+
+// startBotTurn returns a command that takes a turn for the bot of the current player, where the model has been flagged
+// as awaiting a bot turn, so that nothing reads or modifies the game until the bot has taken its turn
+func (m qubicModel) startBotTurn() tea.Cmd {
+	if !m.botTurn {
+		return nil
+	}
+	ctx, delay, game := m.ctx, m.botDelay, m.game
+	return func() tea.Msg {
+		// Delay allows turns to be followed when watching bots play each other
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		state, player, err := game.AllowBotTurnContext(ctx)
+		if ctx.Err() != nil {
+			// Game has been restarted or quit so nothing is awaiting the bot turn
+			return nil
+		}
+		return qubicBotTurnMsg{
+			err:    err,
+			game:   game,
+			player: player,
+			state:  state,
+		}
+	}
+}
+
+// cellSize returns the largest cellSize at which all layers, separated by a gap, fit the terminal
+func (m qubicModel) cellSize() cellSize {
+	if m.height == 0 || m.width == 0 {
+		// Terminal size is not yet known
+		return cellSizes[0]
+	}
+	width := m.width - m.styles.board.GetHorizontalMargins() - (int(qubic.Size) - 1)
+	height := m.height - m.styles.board.GetVerticalMargins() - m.styles.message.GetVerticalFrameSize() -
+		m.styles.message.GetHeight() - lipgloss.Height(m.styles.help.Render(m.help.View(m.keys)))
+	for _, size := range cellSizes {
+		if size.outerWidth()*int(qubicColumns) <= width && size.outerHeight()*int(qubic.Size) <= height {
+			return size
+		}
+	}
+	return cellSizes[len(cellSizes)-1]
+}
+
+// cursorCell returns the qubic.Cell under the cursor
+func (m qubicModel) cursorCell() qubic.Cell {
+	return qubic.Cell{
+		Column: m.cursorX % qubic.Size,
+		Layer:  m.cursorX / qubic.Size,
+		Row:    m.cursorY,
+	}
+}
+
+// play plays the cell under the cursor for the current player
+func (m qubicModel) play() (tea.Model, tea.Cmd) {
+	state, player, err := m.game.Play(qubic.Turn{
+		Cell:   m.cursorCell(),
+		Player: m.player,
+	})
+	return m.played(state, player, err)
+}
+
+// played updates the model following a change to the game, requesting a turn from the bot for the next player, where
+// applicable
+func (m qubicModel) played(state tictactoe.State, player tictactoe.Player, err error) (tea.Model, tea.Cmd) {
+	m.board = m.game.Board()
+	m.err = err
+	m.gameOver = state != tictactoe.StateAwaitingTurn
+	m.botTurn = !m.gameOver && m.game.IsBotTurn()
+	m.player = player
+	m.state = state
+	m.winningCells = m.game.WinningCells()
+	return m, m.startBotTurn()
+}
+
+// renderBoard renders every layer side by side, starting with the first, separated by a gap
+func (m qubicModel) renderBoard() string {
+	size := m.cellSize()
+	cell, cellFocus := size.apply(m.styles.cell), size.apply(m.styles.cellFocus)
+	cellError, cellWin := size.apply(m.styles.cellError), size.apply(m.styles.cellWin)
+	cursor := m.cursorCell()
+
+	layers := make([]string, 0, 2*int(qubic.Size)-1)
+	for layer := uint8(0); layer < qubic.Size; layer++ {
+		rows := make([]string, qubic.Size)
+		for row := uint8(0); row < qubic.Size; row++ {
+			cells := make([]string, qubic.Size)
+			for col := uint8(0); col < qubic.Size; col++ {
+				c := qubic.Cell{Column: col, Layer: layer, Row: row}
+				var style lipgloss.Style
+				if c == cursor && !(m.botTurn || m.gameOver) {
+					if m.err != nil {
+						style = cellError
+					} else {
+						style = cellFocus
+					}
+				} else if slices.Contains(m.winningCells, c) {
+					style = cellWin
+				} else {
+					style = cell
+				}
+				value := style.Render(m.board.PlayerAt(c).String())
+				cells[col] = markCellZone(m.zone, m.zoneIds, int(row), int(layer*qubic.Size+col), value)
+			}
+			rows[row] = lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+		}
+		if layer > 0 {
+			layers = append(layers, strings.TrimSuffix(strings.Repeat(" \n", lipgloss.Height(rows[0])*len(rows)), "\n"))
+		}
+		layers = append(layers, lipgloss.JoinVertical(lipgloss.Left, rows...))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, layers...)
+}
+
+func initQubicModel(opts []qubic.Option, seeder *seeder, botDelay time.Duration, zm *zone.Manager) qubicModel {
+	// Each game has its own seed so that the same random decisions are not made when game is restarted
+	g := qubic.MustStart(append([]qubic.Option{qubic.WithSeed(seeder.next())}, opts...)...)
+
+	km := newKeyMap()
+	// Qubic games cannot be analyzed, saved, or have their turns reverted
+	for _, binding := range []*key.Binding{&km.command, &km.hint, &km.redo, &km.save, &km.undo} {
+		binding.SetEnabled(false)
+	}
+
+	h := help.New()
+	h.Styles.FullKey.Bold(true)
+	h.Styles.ShortKey.Bold(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return qubicModel{
+		board:    g.Board(),
+		botDelay: botDelay,
+		botTurn:  g.IsBotTurn(),
+		cancel:   cancel,
+		ctx:      ctx,
+		cursorX:  qubic.Size / 2,
+		cursorY:  qubic.Size / 2,
+		game:     g,
+		gameOver: g.State() != tictactoe.StateAwaitingTurn,
+		help:     h,
+		keys:     km,
+		opts:     opts,
+		player:   g.Player(),
+		seeder:   seeder,
+		state:    g.State(),
+		styles:   newStyles(),
+		zone:     zm,
+		zoneIds:  make(map[string]struct{}),
+	}
+}
+
+// playQubic plays 3D tic-tac-toe, where opts are derived from the applicable flags, either using the UI or,
+// where headless, by printing the board after each bot turn followed by the result
+func playQubic(opts []qubic.Option, seeder *seeder, botDelay time.Duration, headless, noMouse bool) {
+	if headless {
+		g := qubic.MustStart(append([]qubic.Option{qubic.WithSeed(seeder.next())}, opts...)...)
+		if g.Bot(tictactoe.PlayerOne) == nil || g.Bot(tictactoe.PlayerTwo) == nil {
+			handleInvalidFlag(flagNameHeadless, headless, flagInvalidReasonBotsRequired)
+		}
+		fmt.Printf("%s\n\n", g)
+		for g.IsBotTurn() {
+			time.Sleep(botDelay)
+			player := g.Player()
+			if _, _, err := g.AllowBotTurn(); err != nil {
+				// Built-in bots should never cause errors to return
+				panic(err)
+			}
+			turn, _ := g.LastTurn()
+			fmt.Printf("%s %s\n%s\n\n", player, turn.Cell, g)
+		}
+		switch g.State() {
+		case tictactoe.StateDraw:
+			fmt.Println("DRAW!")
+		case tictactoe.StateWon:
+			fmt.Println(renderPlayer(g.Player()) + " WINS!")
+		}
+		return
+	}
+
+	zm := zone.New()
+	zm.SetEnabled(!noMouse)
+	defer zm.Close()
+
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !noMouse {
+		programOpts = append(programOpts, tea.WithMouseAllMotion())
+	}
+
+	p := tea.NewProgram(initQubicModel(opts, seeder, botDelay, zm), programOpts...)
+	if _, err := p.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// qubicOptions returns the options for 3D tic-tac-toe derived from the given flags
+func qubicOptions(bot1Flag, bot2Flag, bot2FlagName string, player tictactoe.Player) []qubic.Option {
+	opts := []qubic.Option{qubic.WithStarterPlayer(player)}
+	for _, b := range []struct {
+		flagName, name string
+		player         tictactoe.Player
+	}{
+		{flagNameBot1, bot1Flag, tictactoe.PlayerOne},
+		{bot2FlagName, bot2Flag, tictactoe.PlayerTwo},
+	} {
+		if b.name == "" {
+			continue
+		}
+		bot, err := qubic.NewBot(b.name, b.player)
+		if err != nil {
+			handleInvalidFlag(b.flagName, b.name, flagInvalidReasonParse)
+		}
+		opts = append(opts, qubic.WithBot(bot))
+	}
+	return opts
+}
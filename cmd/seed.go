@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// seeder provides the seed for each game played within a session, where the first game uses the seed of the session and
+// each following game (e.g. on restart) uses a new seed derived from it so that the whole session can be replayed using
+// the seed of the session, while any single game can be replayed using its own seed
+type seeder struct {
+	rand    *rand.Rand
+	seeds   []int64
+	session int64
+}
+
+// next returns the seed for the next game
+func (s *seeder) next() int64 {
+	seed := s.session
+	if len(s.seeds) > 0 {
+		seed = s.rand.Int63()
+	}
+	s.seeds = append(s.seeds, seed)
+	return seed
+}
+
+// print prints the seed of each game played
+func (s *seeder) print() {
+	for i, seed := range s.seeds {
+		fmt.Printf("seed (game %d): %d\n", i+1, seed)
+	}
+}
+
+func newSeeder(session int64) *seeder {
+	return &seeder{
+		rand:    rand.New(rand.NewSource(session)),
+		session: session,
+	}
+}
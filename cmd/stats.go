@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/ratings"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+)
+
+// ratingsObserver is a tictactoe.Observer that tracks each game once it's over so that it can be rated, along with
+// saving the ratings to a file, using save.
+//
+// Games are not rated when they're over since the result can still change following an undo, so each game is only
+// rated once finished with, using its final result. Ratings are not saved when each game is over either, since the
+// observer is notified while the lock of a concurrency-safe game is held, which would block any other use of the game
+// while writing to the file.
+type ratingsObserver struct {
+	tictactoe.NopObserver
+	mu      sync.Mutex
+	over    []tictactoe.Game
+	path    string
+	profile string
+	ratings *ratings.Ratings
+}
+
+// GameOver tracks the given game so that it's rated by save, unless it's already tracked
+func (o *ratingsObserver) GameOver(game tictactoe.Game, _ tictactoe.State, _ tictactoe.Player) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !slices.Contains(o.over, game) {
+		o.over = append(o.over, game)
+	}
+}
+
+// save rates each game that has been over, where it still is, before writing ratings to the file, printing any error
+// that occurs. It must only be called once the games are finished with.
+//
+// Each player is named after the profile where they're human, otherwise after their bot, so games between bots are
+// rated as well as those against a profile.
+func (o *ratingsObserver) save() {
+	o.mu.Lock()
+	over := o.over
+	o.over = nil
+	o.mu.Unlock()
+
+	// Lock is not held while reading each game since the observer is notified while the lock of the game is held
+	var changed bool
+	for _, game := range over {
+		var playerOne, playerTwo string
+		if game.Bot(tictactoe.PlayerOne) == nil {
+			playerOne = o.profile
+		}
+		if game.Bot(tictactoe.PlayerTwo) == nil {
+			playerTwo = o.profile
+		}
+		// Games cannot be rated if they're no longer over following an undo, without a profile for every human, nor
+		// between two humans or bots sharing the same name
+		if _, _, err := o.ratings.Update(game, playerOne, playerTwo); err == nil {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := o.ratings.Save(o.path); err != nil {
+		fmt.Printf("failed to save ratings to %s: %v\n", o.path, err)
+	}
+}
+
+func newRatingsObserver(r *ratings.Ratings, path, profile string) *ratingsObserver {
+	return &ratingsObserver{
+		path:    path,
+		profile: profile,
+		ratings: r,
+	}
+}
+
+// printStats prints the leaderboard of each variant within the given ratings, highlighting the given profile, where
+// present
+func printStats(w io.Writer, r *ratings.Ratings, profile string) error {
+	variants := r.Variants()
+	if len(variants) == 0 {
+		_, err := fmt.Fprintln(w, "no rated games")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VARIANT\tRANK\tNAME\tRATING\tGAMES\tWINS\tDRAWS\tLOSSES\t")
+	for _, variant := range variants {
+		for i, e := range r.Leaderboard(variant) {
+			var marker string
+			if profile != "" && e.Name == profile {
+				marker = "*"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%.0f\t%d\t%d\t%d\t%d\t%s\n", variant, i+1, e.Name, e.Rating, e.Games(), e.Wins,
+				e.Draws, e.Losses, marker)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/ultimate"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ultimateCells is the number of cells in each row and column across all sub-boards
+const ultimateCells = ultimate.Size * ultimate.Size
+
+type ultimateBotTurnMsg struct {
+	err    error
+	game   ultimate.Game
+	player tictactoe.Player
+	state  tictactoe.State
+}
+
+// ultimateView contains everything needed to render an ultimate.Game, captured whenever it changes so that it's never
+// read while a bot is taking its turn in another goroutine
+type ultimateView struct {
+	boards       []tictactoe.Board
+	legal        []ultimate.Move
+	meta         tictactoe.Board
+	states       []tictactoe.State
+	winningCells tictactoe.Cells
+}
+
+func newUltimateView(game ultimate.Game) ultimateView {
+	v := ultimateView{
+		legal:        game.LegalMoves(),
+		meta:         game.MetaBoard(),
+		winningCells: game.WinningCells(),
+	}
+	for row := uint8(0); row < ultimate.Size; row++ {
+		for col := uint8(0); col < ultimate.Size; col++ {
+			cell := tictactoe.Cell{Column: col, Row: row}
+			board, _ := game.Board(cell)
+			state, _, _ := game.BoardState(cell)
+			v.boards = append(v.boards, board)
+			v.states = append(v.states, state)
+		}
+	}
+	return v
+}
+
+type ultimateModel struct {
+	botDelay         time.Duration
+	botErr           error
+	botTurn          bool
+	cancel           context.CancelFunc
+	ctx              context.Context
+	cursorX, cursorY uint8
+	err              error
+	game             ultimate.Game
+	gameOver         bool
+	height, width    int
+	help             help.Model
+	keys             keyMap
+	opts             []ultimate.Option
+	player           tictactoe.Player
+	seeder           *seeder
+	state            tictactoe.State
+	styles           styles
+	view             ultimateView
+	zone             *zone.Manager
+	zoneIds          map[string]struct{}
+}
+
+func (m ultimateModel) Init() tea.Cmd {
+	return tea.Batch(tea.SetWindowTitle("tic-tac-toe (ultimate)"), m.startBotTurn())
+}
+
+func (m ultimateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ultimateBotTurnMsg:
+		// Ignore any bot turn for a previous game (e.g. before restart)
+		if msg.game == m.game && !m.gameOver {
+			if msg.err != nil {
+				// Bot is not asked to take its turn again if it failed, otherwise it would likely fail again and again
+				m.botErr = msg.err
+				m.botTurn = false
+				return m, nil
+			}
+			return m.played(msg.state, msg.player, nil)
+		}
+	case tea.KeyMsg:
+		m.botErr = nil
+		switch {
+		case key.Matches(msg, m.keys.choose):
+			if !(m.botTurn || m.gameOver) {
+				return m.play()
+			}
+		case key.Matches(msg, m.keys.up):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorY = (m.cursorY + ultimateCells - 1) % ultimateCells
+			}
+		case key.Matches(msg, m.keys.down):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorY = (m.cursorY + 1) % ultimateCells
+			}
+		case key.Matches(msg, m.keys.left):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorX = (m.cursorX + ultimateCells - 1) % ultimateCells
+			}
+		case key.Matches(msg, m.keys.right):
+			if !(m.botTurn || m.gameOver) {
+				m.err = nil
+				m.cursorX = (m.cursorX + 1) % ultimateCells
+			}
+		case key.Matches(msg, m.keys.resign):
+			if !(m.botTurn || m.gameOver) {
+				state, player, err := m.game.Resign(m.player)
+				return m.played(state, player, err)
+			}
+		case key.Matches(msg, m.keys.restart):
+			m.cancel()
+			nm := initUltimateModel(m.opts, m.seeder, m.botDelay, m.zone)
+			nm.height, nm.width = m.height, m.width
+			nm.help.Width = m.help.Width
+			return nm, nm.startBotTurn()
+		case key.Matches(msg, m.keys.help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, m.keys.quit):
+			m.cancel()
+			return m, tea.Quit
+		}
+	case tea.MouseMsg:
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			if !(m.botTurn || m.gameOver) {
+				if row, col, found := findCellZone(m.zone, m.zoneIds, msg); found {
+					m.cursorX = col
+					m.cursorY = row
+				}
+			}
+		case tea.MouseActionRelease:
+			if !(m.botTurn || m.gameOver) && msg.Button == tea.MouseButtonLeft {
+				if row, col, found := findCellZone(m.zone, m.zoneIds, msg); found {
+					m.cursorX = col
+					m.cursorY = row
+					return m.play()
+				}
+			}
+		default:
+			// Do nothing
+		}
+	case tea.WindowSizeMsg:
+		m.height, m.width = msg.Height, msg.Width
+		m.help.Width = msg.Width
+	}
+	return m, nil
+}
+
+func (m ultimateModel) View() string {
+	board := m.renderBoard()
+	m.styles = m.styles.withMessageWidth(max(lipgloss.Width(board), minMessageWidth))
+	b := m.styles.board.Render(board)
+	var msg string
+	if m.botErr != nil {
+		msg = m.styles.messageError.Render(renderPlayer(m.player) + " FAILED!")
+	} else if m.gameOver {
+		switch m.state {
+		case tictactoe.StateDraw:
+			msg = m.styles.messageDraw.Render("DRAW!")
+		case tictactoe.StateForfeited:
+			msg = m.styles.messageForfeit.Render(renderPlayer(m.player) + " FORFEITS!")
+		case tictactoe.StateWon:
+			msg = m.styles.messageWin.Render(renderPlayer(m.player) + " WINS!")
+		default:
+			panic(fmt.Errorf("unexpected final game state: %v", m.state))
+		}
+	} else if m.botTurn {
+		msg = m.styles.message.Render(renderPlayer(m.player) + " THINKING...")
+	} else {
+		msg = m.styles.message.Render("READY " + renderPlayer(m.player))
+	}
+	h := m.styles.help.Render(m.help.View(m.keys))
+	return m.zone.Scan(lipgloss.JoinVertical(lipgloss.Top, b, msg, h))
+}
+
+// startBotTurn returns a command that takes a turn for the bot of the current player, where the model has been flagged
+// as awaiting a bot turn, so that nothing reads or modifies the game until the bot has taken its turn
+func (m ultimateModel) startBotTurn() tea.Cmd {
+	if !m.botTurn {
+		return nil
+	}
+	ctx, delay, game := m.ctx, m.botDelay, m.game
+	return func() tea.Msg {
+		// Delay allows turns to be followed when watching bots play each other
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		state, player, err := game.AllowBotTurnContext(ctx)
+		if ctx.Err() != nil {
+			// Game has been restarted or quit so nothing is awaiting the bot turn
+			return nil
+		}
+		return ultimateBotTurnMsg{
+			err:    err,
+			game:   game,
+			player: player,
+			state:  state,
+		}
+	}
+}
+
+// cellSize returns the largest cellSize at which all sub-boards, separated by a gap, fit the terminal
+func (m ultimateModel) cellSize() cellSize {
+	if m.height == 0 || m.width == 0 {
+		// Terminal size is not yet known
+		return cellSizes[0]
+	}
+	gaps := int(ultimate.Size) - 1
+	width := m.width - m.styles.board.GetHorizontalMargins() - gaps
+	height := m.height - m.styles.board.GetVerticalMargins() - m.styles.message.GetVerticalFrameSize() -
+		m.styles.message.GetHeight() - lipgloss.Height(m.styles.help.Render(m.help.View(m.keys))) - gaps
+	for _, size := range cellSizes {
+		if size.outerWidth()*int(ultimateCells) <= width && size.outerHeight()*int(ultimateCells) <= height {
+			return size
+		}
+	}
+	return cellSizes[len(cellSizes)-1]
+}
+
+// cursorMove returns the ultimate.Move for the cell under the cursor
+func (m ultimateModel) cursorMove() ultimate.Move {
+	return ultimate.Move{
+		Board: tictactoe.Cell{
+			Column: m.cursorX / ultimate.Size,
+			Row:    m.cursorY / ultimate.Size,
+		},
+		Cell: tictactoe.Cell{
+			Column: m.cursorX % ultimate.Size,
+			Row:    m.cursorY % ultimate.Size,
+		},
+	}
+}
+
+// play plays the cell under the cursor for the current player
+func (m ultimateModel) play() (tea.Model, tea.Cmd) {
+	state, player, err := m.game.Play(ultimate.Turn{
+		Move:   m.cursorMove(),
+		Player: m.player,
+	})
+	return m.played(state, player, err)
+}
+
+// played updates the model following a change to the game, requesting a turn from the bot for the next player, where
+// applicable
+func (m ultimateModel) played(state tictactoe.State, player tictactoe.Player, err error) (tea.Model, tea.Cmd) {
+	m.err = err
+	m.gameOver = state != tictactoe.StateAwaitingTurn
+	m.player = player
+	m.state = state
+	m.botTurn = !m.gameOver && m.game.IsBotTurn()
+	m.view = newUltimateView(m.game)
+	return m, m.startBotTurn()
+}
+
+// renderBoard renders every sub-board, separated by a gap, where each sub-board that has been won is rendered as a
+// single block containing the winning player, and only the cells of sub-boards in which the current player can take
+// their turn are highlighted
+func (m ultimateModel) renderBoard() string {
+	size := m.cellSize()
+	cell, cellAlt := size.apply(m.styles.cell), size.apply(m.styles.cellAlt)
+	cellError, cellFocus := size.apply(m.styles.cellError), size.apply(m.styles.cellFocus)
+	cursor := m.cursorMove()
+	blockWidth, blockHeight := size.outerWidth()*int(ultimate.Size), size.outerHeight()*int(ultimate.Size)
+	block := m.styles.cellAlt.Width(blockWidth).Height(blockHeight)
+	blockWin := m.styles.cellWin.Width(blockWidth).Height(blockHeight)
+
+	metaRows := make([]string, ultimate.Size)
+	for boardRow := uint8(0); boardRow < ultimate.Size; boardRow++ {
+		boards := make([]string, ultimate.Size)
+		for boardCol := uint8(0); boardCol < ultimate.Size; boardCol++ {
+			boardCell := tictactoe.Cell{Column: boardCol, Row: boardRow}
+			i := int(boardRow)*int(ultimate.Size) + int(boardCol)
+			if m.view.states[i] == tictactoe.StateWon {
+				style := block
+				if slices.Contains(m.view.winningCells, boardCell) {
+					style = blockWin
+				}
+				boards[boardCol] = style.Render(m.view.meta[boardRow][boardCol].String())
+				continue
+			}
+
+			rows := make([]string, ultimate.Size)
+			for row := uint8(0); row < ultimate.Size; row++ {
+				cells := make([]string, ultimate.Size)
+				for col := uint8(0); col < ultimate.Size; col++ {
+					move := ultimate.Move{Board: boardCell, Cell: tictactoe.Cell{Column: col, Row: row}}
+					var style lipgloss.Style
+					if move == cursor && !(m.botTurn || m.gameOver) {
+						if m.err != nil {
+							style = cellError
+						} else {
+							style = cellFocus
+						}
+					} else if slices.ContainsFunc(m.view.legal, func(legal ultimate.Move) bool {
+						return legal.Board == boardCell
+					}) && !m.gameOver {
+						style = cell
+					} else {
+						style = cellAlt
+					}
+					value := style.Render(m.view.boards[i][row][col].String())
+					cells[col] = markCellZone(m.zone, m.zoneIds, int(boardRow*ultimate.Size+row),
+						int(boardCol*ultimate.Size+col), value)
+				}
+				rows[row] = lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+			}
+			boards[boardCol] = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		}
+		gap := strings.TrimSuffix(strings.Repeat(" \n", lipgloss.Height(boards[0])), "\n")
+		metaRows[boardRow] = lipgloss.JoinHorizontal(lipgloss.Top, boards[0], gap, boards[1], gap, boards[2])
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, metaRows[0], "", metaRows[1], "", metaRows[2])
+}
+
+func initUltimateModel(opts []ultimate.Option, seeder *seeder, botDelay time.Duration, zm *zone.Manager) ultimateModel {
+	// Each game has its own seed so that the same random decisions are not made when game is restarted
+	g := ultimate.MustStart(append([]ultimate.Option{ultimate.WithSeed(seeder.next())}, opts...)...)
+
+	km := newKeyMap()
+	// Ultimate games cannot be analyzed, saved, or have their turns reverted
+	for _, binding := range []*key.Binding{&km.command, &km.hint, &km.redo, &km.save, &km.undo} {
+		binding.SetEnabled(false)
+	}
+
+	h := help.New()
+	h.Styles.FullKey.Bold(true)
+	h.Styles.ShortKey.Bold(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	center := ultimateCells / 2
+	return ultimateModel{
+		botDelay: botDelay,
+		botTurn:  g.IsBotTurn(),
+		cancel:   cancel,
+		ctx:      ctx,
+		cursorX:  center,
+		cursorY:  center,
+		game:     g,
+		gameOver: g.State() != tictactoe.StateAwaitingTurn,
+		help:     h,
+		keys:     km,
+		opts:     opts,
+		player:   g.Player(),
+		seeder:   seeder,
+		state:    g.State(),
+		styles:   newStyles(),
+		view:     newUltimateView(g),
+		zone:     zm,
+		zoneIds:  make(map[string]struct{}),
+	}
+}
+
+// playUltimate plays ultimate tic-tac-toe, where opts are derived from the applicable flags, either using the UI or,
+// where headless, by printing the board after each bot turn followed by the result
+func playUltimate(opts []ultimate.Option, seeder *seeder, botDelay time.Duration, headless, noMouse bool) {
+	if headless {
+		g := ultimate.MustStart(append([]ultimate.Option{ultimate.WithSeed(seeder.next())}, opts...)...)
+		if g.Bot(tictactoe.PlayerOne) == nil || g.Bot(tictactoe.PlayerTwo) == nil {
+			handleInvalidFlag(flagNameHeadless, headless, flagInvalidReasonBotsRequired)
+		}
+		fmt.Printf("%s\n\n", g)
+		for g.IsBotTurn() {
+			time.Sleep(botDelay)
+			player := g.Player()
+			if _, _, err := g.AllowBotTurn(); err != nil {
+				// Built-in bots should never cause errors to return
+				panic(err)
+			}
+			turn, _ := g.LastTurn()
+			fmt.Printf("%s %s\n%s\n\n", player, turn.Move, g)
+		}
+		switch g.State() {
+		case tictactoe.StateDraw:
+			fmt.Println("DRAW!")
+		case tictactoe.StateWon:
+			fmt.Println(renderPlayer(g.Player()) + " WINS!")
+		}
+		return
+	}
+
+	zm := zone.New()
+	zm.SetEnabled(!noMouse)
+	defer zm.Close()
+
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !noMouse {
+		programOpts = append(programOpts, tea.WithMouseAllMotion())
+	}
+
+	p := tea.NewProgram(initUltimateModel(opts, seeder, botDelay, zm), programOpts...)
+	if _, err := p.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// ultimateOptions returns the options for ultimate tic-tac-toe derived from the given flags
+func ultimateOptions(bot1Flag, bot2Flag, bot2FlagName string, player tictactoe.Player) []ultimate.Option {
+	opts := []ultimate.Option{ultimate.WithStarterPlayer(player)}
+	for _, b := range []struct {
+		flagName, name string
+		player         tictactoe.Player
+	}{
+		{flagNameBot1, bot1Flag, tictactoe.PlayerOne},
+		{bot2FlagName, bot2Flag, tictactoe.PlayerTwo},
+	} {
+		if b.name == "" {
+			continue
+		}
+		bot, err := ultimate.NewBot(b.name, b.player)
+		if err != nil {
+			handleInvalidFlag(b.flagName, b.name, flagInvalidReasonParse)
+		}
+		opts = append(opts, ultimate.WithBot(bot))
+	}
+	return opts
+}
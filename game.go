@@ -1,18 +1,26 @@
 package tictactoe
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/neocotic/go-tic-tac-toe/internal/condition"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"github.com/neocotic/go-tic-tac-toe/internal/random"
 	"math"
 	"math/rand"
+	"slices"
 	"strings"
 )
 
 const (
-	// MaxSize is the maximum size of a Board
+	// MaxSize is the maximum size (i.e. number of rows or columns) of a Board
 	MaxSize uint8 = math.MaxUint8
-	// MinSize is the minimum size of a Board
+	// MinSize is the minimum size (i.e. number of rows or columns) of a Board
 	MinSize uint8 = 3
+	// MinWinLength is the minimum number of consecutive cells a Player can be required to occupy to win
+	MinWinLength uint8 = 3
 )
 
 // Board contains all player turns
@@ -23,10 +31,9 @@ func (b Board) Copy() Board {
 	if b == nil {
 		return nil
 	}
-	l := len(b)
-	c := make(Board, l)
+	c := make(Board, len(b))
 	for row, cols := range b {
-		c[row] = make([]Player, l)
+		c[row] = make([]Player, len(cols))
 		copy(c[row], cols)
 	}
 	return c
@@ -48,12 +55,43 @@ func (b Board) FindEmpty() Cells {
 	return cells
 }
 
+// FindLowestEmpty returns the lowest Cell (i.e. with the greatest row) within the given column of Board that does not
+// contain a Player, being the Cell in which a turn taken in that column lands where gravity applies (see WithGravity).
+//
+// False is returned if column is out-of-bounds or full.
+func (b Board) FindLowestEmpty(column uint8) (Cell, bool) {
+	for row := len(b) - 1; row >= 0; row-- {
+		if !b.isInBounds(row, int(column)) {
+			return Cell{}, false
+		}
+		if b[row][column] == 0 {
+			return Cell{
+				Column: column,
+				Row:    uint8(row),
+			}, true
+		}
+	}
+	return Cell{}, false
+}
+
+// MarshalJSON returns the JSON encoding of Board as an array of rows, each containing an array of Player values
+func (b Board) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	rows := make([][]uint, len(b))
+	for row, cols := range b {
+		rows[row] = make([]uint, len(cols))
+		for col, player := range cols {
+			rows[row][col] = uint(player)
+		}
+	}
+	return json.Marshal(rows)
+}
+
 // String returns a classic ASCII representation of Board
 func (b Board) String() string {
-	var (
-		sb   strings.Builder
-		size = len(b)
-	)
+	var sb strings.Builder
 	for row, cols := range b {
 		sb.WriteString("|")
 		for _, player := range cols {
@@ -61,9 +99,9 @@ func (b Board) String() string {
 			sb.WriteString(player.String())
 			sb.WriteString(" |")
 		}
-		if row < size-1 {
+		if row < len(b)-1 {
 			sb.WriteString("\n|")
-			for i := 0; i < size; i++ {
+			for i := range cols {
 				if i > 0 {
 					sb.WriteRune('+')
 				}
@@ -75,7 +113,27 @@ func (b Board) String() string {
 	return sb.String()
 }
 
-func (b Board) check() (starter Player, size uint8, maxTurns int, turns []Turn, err error) {
+// UnmarshalJSON decodes the JSON encoding of Board as produced by MarshalJSON
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var rows [][]uint8
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	if rows == nil {
+		*b = nil
+		return nil
+	}
+	*b = make(Board, len(rows))
+	for row, cols := range rows {
+		(*b)[row] = make([]Player, len(cols))
+		for col, player := range cols {
+			(*b)[row][col] = Player(player)
+		}
+	}
+	return nil
+}
+
+func (b Board) check() (starter Player, rows, cols uint8, turns []Turn, err error) {
 	if l := len(b); l < int(MinSize) {
 		err = fmt.Errorf("board must contain at least %d rows", MinSize)
 		return
@@ -83,18 +141,26 @@ func (b Board) check() (starter Player, size uint8, maxTurns int, turns []Turn,
 		err = fmt.Errorf("board must contain at most %d rows", MaxSize)
 		return
 	} else {
-		maxTurns = l * l
-		size = uint8(l)
+		rows = uint8(l)
+	}
+	if l := len(b[0]); l < int(MinSize) {
+		err = fmt.Errorf("board must contain at least %d columns", MinSize)
+		return
+	} else if l > int(MaxSize) {
+		err = fmt.Errorf("board must contain at most %d columns", MaxSize)
+		return
+	} else {
+		cols = uint8(l)
 	}
 
 	turnCounter := make(map[Player]uint16, 2)
-	for row, cols := range b {
-		if l := len(cols); l != int(size) {
-			err = fmt.Errorf("board row[%d] must contain %d columns", row, size)
+	for row, rowCols := range b {
+		if l := len(rowCols); l != int(cols) {
+			err = fmt.Errorf("board row[%d] must contain %d columns", row, cols)
 			return
 		}
 
-		for col, player := range cols {
+		for col, player := range rowCols {
 			if player == 0 {
 				continue
 			}
@@ -130,10 +196,55 @@ func (b Board) check() (starter Player, size uint8, maxTurns int, turns []Turn,
 	return
 }
 
-func newBoard(size uint8) Board {
-	board := make(Board, size)
+// findFloating returns the first Cell within Board containing a Player while the Cell below it does not, which would
+// be impossible where gravity applies, if any
+func (b Board) findFloating() (Cell, bool) {
+	for row := 0; row < len(b)-1; row++ {
+		for col, player := range b[row] {
+			if player > 0 && b[row+1][col] == 0 {
+				return Cell{
+					Column: uint8(col),
+					Row:    uint8(row),
+				}, true
+			}
+		}
+	}
+	return Cell{}, false
+}
+
+// findLegal returns all Cells within Board in which a turn can be taken, being all empty Cells or, where gravity
+// applies, only the lowest empty Cell within each column.
+func (b Board) findLegal(gravity bool) Cells {
+	if !gravity {
+		return b.FindEmpty()
+	}
+	var cells Cells
+	if len(b) > 0 {
+		for col := range b[0] {
+			if cell, ok := b.FindLowestEmpty(uint8(col)); ok {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+func (b Board) isInBounds(row, col int) bool {
+	return row >= 0 && row < len(b) && col >= 0 && col < len(b[row])
+}
+
+func (b Board) playerAt(row, col int) Player {
+	if !b.isInBounds(row, col) {
+		return 0
+	}
+	return b[row][col]
+}
+
+// NewBoard returns an empty Board with the given number of rows and columns
+func NewBoard(rows, cols uint8) Board {
+	board := make(Board, rows)
 	for i := range board {
-		board[i] = make([]Player, size)
+		board[i] = make([]Player, cols)
 	}
 	return board
 }
@@ -142,16 +253,18 @@ type (
 	// Cell represents the location of a cell on a Board
 	Cell struct {
 		// Column is the column of Cell
-		Column uint8
+		Column uint8 `json:"column"`
 		// Row is the row of Cell
-		Row uint8
+		Row uint8 `json:"row"`
 	}
 
 	// Cells contains multiple Board cells
 	Cells []Cell
 )
 
-// Random returns a random Cell within Cells or an empty Cell if Cells is empty
+// Random returns a random Cell within Cells or an empty Cell if Cells is empty.
+//
+// The global source of randomness is used, so RandomFrom should be used instead where results must be reproducible.
 func (cs Cells) Random() Cell {
 	if len(cs) == 0 {
 		return Cell{}
@@ -159,6 +272,15 @@ func (cs Cells) Random() Cell {
 	return cs[rand.Intn(len(cs))]
 }
 
+// RandomFrom returns a random Cell within Cells, using the given source of randomness, or an empty Cell if Cells is
+// empty
+func (cs Cells) RandomFrom(r *rand.Rand) Cell {
+	if len(cs) == 0 {
+		return Cell{}
+	}
+	return cs[r.Intn(len(cs))]
+}
+
 type (
 	// Condition represents a check for a specific winning condition
 	Condition interface {
@@ -176,6 +298,17 @@ type (
 
 	// Conditions contains multiple winning conditions
 	Conditions []Condition
+
+	// WinningCellsCondition is an optional interface that may be implemented by a Condition to report the Cells that
+	// resulted in a win
+	WinningCellsCondition interface {
+		Condition
+		// WinningCells checks the given Board and returns the Cells that resulted in the Turn provided being a winning
+		// turn based on the Condition, or nil if it was not a winning turn.
+		//
+		// The Board provided is not a copy so a Condition must never mutate it or risk corrupting the Game.
+		WinningCells(board Board, turn Turn) Cells
+	}
 )
 
 // FindWinner checks the given Board and returns the Player that wins based on any of the Conditions or zero if there is
@@ -185,14 +318,7 @@ type (
 //
 // An ErrConditionInvalid is returned if a Condition returns an invalid non-zero Player.
 func (cs Conditions) FindWinner(board Board) (Player, error) {
-	for i, c := range cs {
-		if player := c.FindWinner(board); !player.IsValidOrZero() {
-			return 0, fmtInvalidConditionErr(i, fmt.Sprintf("invalid player: %v", player))
-		} else if player > 0 {
-			return player, nil
-		}
-	}
-	return 0, nil
+	return condition.FindWinner[Board, Turn, Player](cs, board)
 }
 
 // IsWinningTurn checks the given Board and returns whether the Turn provided resulted in a win based on any of the
@@ -200,206 +326,212 @@ func (cs Conditions) FindWinner(board Board) (Player, error) {
 //
 // The Board provided is not a copy so if a Condition mutates it they will corrupt the Game.
 func (cs Conditions) IsWinningTurn(board Board, turn Turn) bool {
-	for _, c := range cs {
-		if won := c.IsWinningTurn(board, turn); won {
-			return won
-		}
-	}
-	return false
+	return condition.IsWinningTurn[Board, Turn, Player](cs, board, turn)
+}
+
+// WinningCells checks the given Board and returns the Cells that resulted in the Turn provided being a winning turn
+// based on any of the Conditions that implement WinningCellsCondition, or nil if there are none.
+//
+// The Board provided is not a copy so if a Condition mutates it they will corrupt the Game.
+func (cs Conditions) WinningCells(board Board, turn Turn) Cells {
+	return condition.WinningCells[Board, Turn, Player, Cell, Cells](cs, board, turn)
+}
+
+// StandardConditions returns the winning Conditions used by every Game, where a Player must occupy the given number of
+// consecutive cells in any row, column, or diagonal to win.
+//
+// They can also be used to check any other Board in the same way (e.g. for a variant built on top of Game).
+func StandardConditions(length uint8) Conditions {
+	return newStandardConditions(length)
 }
 
-var standardConditions = Conditions{&horizontalCondition{}, &verticalCondition{}, &diagonalCondition{}}
+func newStandardConditions(length uint8) Conditions {
+	return Conditions{&horizontalCondition{length}, &verticalCondition{length}, &diagonalCondition{length}}
+}
 
-type diagonalCondition struct{}
+type diagonalCondition struct {
+	length uint8
+}
 
 func (c *diagonalCondition) FindWinner(board Board) Player {
-	if winner := c.findWinnerFromLeft(board); winner > 0 {
-		return winner
-	}
-	if winner := c.findWinnerFromRight(board); winner > 0 {
+	if winner := findLineWinner(board, c.length, 1, 1); winner > 0 {
 		return winner
 	}
-	return 0
+	return findLineWinner(board, c.length, 1, -1)
 }
 
 func (c *diagonalCondition) IsWinningTurn(board Board, turn Turn) bool {
-	if turn.Row == turn.Column && c.isWinningTurnFromLeft(board, turn) {
-		return true
-	}
-	if turn.Row == uint8(len(board))-turn.Column-1 && c.isWinningTurnFromRight(board, turn) {
-		return true
-	}
-	return false
+	return isLineWinningTurn(board, turn, c.length, 1, 1) || isLineWinningTurn(board, turn, c.length, 1, -1)
 }
 
-func (c *diagonalCondition) findWinnerFromLeft(board Board) (player Player) {
-	for i, cols := range board {
-		current := cols[i]
-		if current == 0 {
-			return 0
-		}
-		if player == 0 {
-			player = current
-		} else if current != player {
-			return 0
-		}
-	}
-	return
+func (c *diagonalCondition) WinningCells(board Board, turn Turn) Cells {
+	return append(findLineWinningCells(board, turn, c.length, 1, 1), findLineWinningCells(board, turn, c.length, 1, -1)...)
 }
 
-func (c *diagonalCondition) findWinnerFromRight(board Board) (player Player) {
-	l := len(board)
-	for i, cols := range board {
-		current := cols[l-i-1]
-		if current == 0 {
-			return 0
-		}
-		if player == 0 {
-			player = current
-		} else if current != player {
-			return 0
-		}
-	}
-	return
+type horizontalCondition struct {
+	length uint8
 }
 
-func (c *diagonalCondition) isWinningTurnFromLeft(board Board, turn Turn) bool {
-	for i, cols := range board {
-		if cols[i] != turn.Player {
-			return false
-		}
-	}
-	return true
+func (c *horizontalCondition) FindWinner(board Board) Player {
+	return findLineWinner(board, c.length, 0, 1)
 }
 
-func (c *diagonalCondition) isWinningTurnFromRight(board Board, turn Turn) bool {
-	l := len(board)
-	for i, cols := range board {
-		if cols[l-i-1] != turn.Player {
-			return false
-		}
-	}
-	return true
+func (c *horizontalCondition) IsWinningTurn(board Board, turn Turn) bool {
+	return isLineWinningTurn(board, turn, c.length, 0, 1)
 }
 
-type horizontalCondition struct{}
+func (c *horizontalCondition) WinningCells(board Board, turn Turn) Cells {
+	return findLineWinningCells(board, turn, c.length, 0, 1)
+}
 
-func (c *horizontalCondition) FindWinner(board Board) Player {
-	for _, cols := range board {
-		if winner := c.findWinnerFromRow(cols); winner > 0 {
-			return winner
-		}
-	}
-	return 0
+type verticalCondition struct {
+	length uint8
 }
 
-func (c *horizontalCondition) IsWinningTurn(board Board, turn Turn) bool {
-	for _, current := range board[turn.Row] {
-		if current != turn.Player {
-			return false
-		}
-	}
-	return true
+func (c *verticalCondition) FindWinner(board Board) Player {
+	return findLineWinner(board, c.length, 1, 0)
 }
 
-func (c *horizontalCondition) findWinnerFromRow(cols []Player) (player Player) {
-	for _, current := range cols {
-		if current == 0 {
-			return 0
-		}
-		if player == 0 {
-			player = current
-		} else if player != current {
-			return 0
-		}
+func (c *verticalCondition) IsWinningTurn(board Board, turn Turn) bool {
+	return isLineWinningTurn(board, turn, c.length, 1, 0)
+}
+
+func (c *verticalCondition) WinningCells(board Board, turn Turn) Cells {
+	return findLineWinningCells(board, turn, c.length, 1, 0)
+}
+
+// countLine returns the number of consecutive cells occupied by player on board, starting from the given row and column
+// and heading in the direction of the given row and column deltas.
+func countLine(board Board, row, col, dRow, dCol int, player Player) (count int) {
+	for player > 0 && board.playerAt(row, col) == player {
+		count++
+		row += dRow
+		col += dCol
 	}
 	return
 }
 
-type verticalCondition struct{}
-
-func (c *verticalCondition) FindWinner(board Board) Player {
-	for i := range board {
-		if winner := c.findWinnerFromCol(board, uint8(i)); winner > 0 {
-			return winner
+// findLineWinner returns the Player that occupies at least length consecutive cells anywhere on board in the direction
+// of the given row and column deltas, or zero if there is no such Player.
+func findLineWinner(board Board, length uint8, dRow, dCol int) Player {
+	for row, cols := range board {
+		for col, player := range cols {
+			// Only count lines from their first cell
+			if player == 0 || board.playerAt(row-dRow, col-dCol) == player {
+				continue
+			}
+			if countLine(board, row, col, dRow, dCol, player) >= int(length) {
+				return player
+			}
 		}
 	}
 	return 0
 }
 
-func (c *verticalCondition) IsWinningTurn(board Board, turn Turn) bool {
-	for _, cols := range board {
-		if cols[turn.Column] != turn.Player {
-			return false
-		}
+// findLineWinningCells returns all consecutive cells occupied by the Player of the given Turn on board in the direction
+// (either way) of the given row and column deltas, provided that there are at least length of them, otherwise nil.
+func findLineWinningCells(board Board, turn Turn, length uint8, dRow, dCol int) Cells {
+	if !isLineWinningTurn(board, turn, length, dRow, dCol) {
+		return nil
+	}
+	row, col := int(turn.Row), int(turn.Column)
+	back := countLine(board, row, col, -dRow, -dCol, turn.Player) - 1
+	row, col = row-back*dRow, col-back*dCol
+	var cells Cells
+	for board.playerAt(row, col) == turn.Player {
+		cells = append(cells, Cell{
+			Column: uint8(col),
+			Row:    uint8(row),
+		})
+		row += dRow
+		col += dCol
 	}
-	return true
+	return cells
 }
 
-func (c *verticalCondition) findWinnerFromCol(board Board, col uint8) (player Player) {
-	for _, cols := range board {
-		current := cols[col]
-		if current == 0 {
-			return 0
-		}
-		if player == 0 {
-			player = current
-		} else if player != current {
-			return 0
-		}
+// isLineWinningTurn returns whether the given Turn resulted in its Player occupying at least length consecutive cells on
+// board in the direction (either way) of the given row and column deltas.
+func isLineWinningTurn(board Board, turn Turn, length uint8, dRow, dCol int) bool {
+	row, col := int(turn.Row), int(turn.Column)
+	if board.playerAt(row, col) != turn.Player {
+		return false
 	}
-	return
+	count := countLine(board, row, col, dRow, dCol, turn.Player) + countLine(board, row, col, -dRow, -dCol, turn.Player) - 1
+	return count >= int(length)
 }
 
 var (
 	// ErrBot is returned if a Bot fails to take their turn
-	ErrBot = errors.New("bot turn failed")
+	ErrBot = errs.ErrBot
 	// ErrBotMaxSizeExceeded is returned if a Bot is used in combination with a board whose size exceeds its maximum
-	ErrBotMaxSizeExceeded = errors.New("bot max board size exceeded")
+	ErrBotMaxSizeExceeded = errs.ErrBotMaxSizeExceeded
+	// ErrBotNotFound is returned if a Bot with a given name is neither built-in nor registered (see RegisterBot)
+	ErrBotNotFound = errs.ErrBotNotFound
+	// ErrBotRegistered is returned if attempting to register a Bot using a name that is already in use
+	ErrBotRegistered = errs.ErrBotRegistered
 	// ErrConditionInvalid is returned if a Condition returns invalid information
-	ErrConditionInvalid = errors.New("invalid condition")
-	// ErrGameOver is returned if attempting to take a turn while not having StateAwaitingTurn
-	ErrGameOver = errors.New("game over")
+	ErrConditionInvalid = errs.ErrConditionInvalid
+	// ErrGameOver is returned if attempting to take a turn or resign while not having StateAwaitingTurn, or to undo or
+	// redo a turn once a Player has resigned
+	ErrGameOver = errs.ErrGameOver
+	// ErrNotationInvalid is returned if attempting to parse compact notation that is malformed or invalid
+	ErrNotationInvalid = errs.ErrNotationInvalid
+	// ErrNothingToRedo is returned if attempting to redo a turn when no turn has been undone
+	ErrNothingToRedo = errs.ErrNothingToRedo
+	// ErrNothingToUndo is returned if attempting to undo a turn when no turn has been played
+	ErrNothingToUndo = errs.ErrNothingToUndo
 	// ErrOptionInvalid is returned if an Option is passed to Start that has been given an invalid argument
-	ErrOptionInvalid = errors.New("invalid option")
+	ErrOptionInvalid = errs.ErrOptionInvalid
 	// ErrOutOfBounds is returned if a given row/column is out-of-bounds
-	ErrOutOfBounds = errors.New("out of bounds")
+	ErrOutOfBounds = errs.ErrOutOfBounds
 	// ErrPlayerNotFound is returned if a given Player cannot be found
-	ErrPlayerNotFound = errors.New("player not found")
+	ErrPlayerNotFound = errs.ErrPlayerNotFound
 	// ErrTurnInvalid is returned if attempting to take an invalid turn
-	ErrTurnInvalid = errors.New("invalid turn")
+	ErrTurnInvalid = errs.ErrTurnInvalid
 )
 
 func fmtBotErr(bot Bot, err error) error {
-	return fmt.Errorf("%q %w: %w", bot.Name(), ErrBot, err)
+	return errs.Bot(bot.Name(), err)
 }
 
 func fmtBotMaxSizeExceededErr(bot Bot) error {
-	return fmt.Errorf("%q %w: %v", bot.Name(), ErrBotMaxSizeExceeded, bot.MaxSize())
+	return errs.BotMaxSizeExceeded(bot.Name(), bot.MaxSize())
+}
+
+func fmtBotNotFoundErr(name string) error {
+	return errs.BotNotFound(name)
+}
+
+func fmtBotRegisteredErr(name string) error {
+	return errs.BotRegistered(name)
 }
 
 func fmtColOutOfBoundsErr(cell Cell, size uint8) error {
-	return fmt.Errorf("%w: row[%d]col[%d] is greater than or equal to %d", ErrOutOfBounds, cell.Row, cell.Column, size)
+	return errs.OutOfBounds(fmt.Sprintf("row[%d]col[%d]", cell.Row, cell.Column), size)
 }
 
 func fmtInvalidConditionErr(idx int, reason string) error {
-	return fmt.Errorf("%w[%d]: %s", ErrConditionInvalid, idx, reason)
+	return errs.InvalidCondition(idx, reason)
+}
+
+func fmtInvalidNotationErr(err error) error {
+	return errs.InvalidNotation(err)
 }
 
 func fmtInvalidOptionErr(option string, err error) error {
-	return fmt.Errorf("%w[%s]: %w", ErrOptionInvalid, option, err)
+	return errs.InvalidOption(option, err)
 }
 
 func fmtInvalidTurnErr(reason string) error {
-	return fmt.Errorf("%w: %s", ErrTurnInvalid, reason)
+	return errs.InvalidTurn(reason)
 }
 
 func fmtPlayerNotFoundErr(player Player) error {
-	return fmt.Errorf("%w: %d", ErrPlayerNotFound, player)
+	return errs.PlayerNotFound(player)
 }
 
 func fmtRowOutOfBoundsErr(cell Cell, size uint8) error {
-	return fmt.Errorf("%w: row[%d] is greater than or equal to %d", ErrOutOfBounds, cell.Row, size)
+	return errs.OutOfBounds(fmt.Sprintf("row[%d]", cell.Row), size)
 }
 
 type (
@@ -407,21 +539,50 @@ type (
 	Game interface {
 		// AllowBotTurn requests a turn from a Bot, where applicable, and plays that Turn.
 		//
-		// Nothing happens if Game doesn't have StateAwaitingTurn, has no Bot, or it's not the turn of the Bot.
+		// Nothing happens if Game doesn't have StateAwaitingTurn or the current Player is not controlled by a Bot. Where
+		// Game has a Bot for each Player, AllowBotTurn must be called for every turn.
 		//
 		// An ErrBot is returned if the Bot fails to take their turn or their turn is invalid due to the same
 		// constraints as applied to Play.
 		AllowBotTurn() (State, Player, error)
+		// AllowBotTurnContext is the same as AllowBotTurn except that the given context can be used to cancel the Bot
+		// taking their turn or to impose a deadline.
+		//
+		// If the Bot implements ContextBot, context is passed to it so that it can stop searching for its turn as soon
+		// as context is done. Otherwise, any turn taken by the Bot after context is done is discarded.
+		//
+		// An ErrBot is returned, also wrapping the error of context, if context is done before the Bot takes their
+		// turn.
+		AllowBotTurnContext(ctx context.Context) (State, Player, error)
 		// Board returns a copy of the Board
 		Board() Board
+		// Bot returns the Bot controlling the given Player, or nil if Player is human
+		Bot(player Player) Bot
+		// Columns returns the number of columns on the Board
+		Columns() uint8
 		// Conditions returns a copy of the winning conditions for Game
 		Conditions() Conditions
-		// IsBotTurn returns whether Game has a Bot, and it's their turn.
+		// HasGravity returns whether Game is played with gravity, where each turn lands in the lowest empty Cell of its
+		// column (see WithGravity)
+		HasGravity() bool
+		// IsBotTurn returns whether the current Player is controlled by a Bot.
 		//
 		// If Game does not have StateAwaitingTurn, false will always be returned.
 		IsBotTurn() bool
+		// IsMisere returns whether Game is played under misère rules, where the Player who completes a line loses (see
+		// WithMisere)
+		IsMisere() bool
 		// LastTurn returns the last Turn played, where possible
 		LastTurn() (Turn, bool)
+		// LegalCells returns the Cells in which the current Player can take their turn, being every empty Cell or,
+		// where Game has gravity (see WithGravity), only the lowest empty Cell within each column.
+		//
+		// Nil is returned if Game doesn't have StateAwaitingTurn.
+		LegalCells() Cells
+		// MarshalJSON returns the JSON encoding of a Snapshot of Game.
+		//
+		// The JSON can be decoded back into a Game using Restore.
+		MarshalJSON() ([]byte, error)
 		// MaxTurns returns the maximum number of turns allowed
 		MaxTurns() int
 		// Play takes the given Turn and returns the resulting State and Player.
@@ -433,13 +594,14 @@ type (
 		// The resulting Player will vary depending on State:
 		//  - For StateAwaitingTurn it's the Player to take the next turn
 		//  - For StateDraw it's zero
-		//  - For StateWon it's the given Player who's won
+		//  - For StateWon it's the given Player who's won, or their opponent under misère rules (see WithMisere)
 		//
 		// An error is returned in following cases:
 		//  - ErrGameOver if Game doesn't have StateAwaitingTurn
 		//  - ErrOutOfBounds if Turn's Cell is out-of-bounds
 		//  - ErrPlayerNotFound if Turn's Player is invalid
-		//  - ErrTurnInvalid if Turn is invalid (e.g. not turn of Player, Cell taken)
+		//  - ErrTurnInvalid if Turn is invalid (e.g. not turn of Player, Cell taken, Cell not lowest empty Cell of its
+		//    column where Game has gravity)
 		Play(turn Turn) (State, Player, error)
 		// Player returns the current Player, where appropriate.
 		//
@@ -447,60 +609,143 @@ type (
 		//  - For StateAwaitingTurn it's the Player to take the next turn
 		//  - For StateDraw it's zero
 		//  - For StateWon it's the winning Player
+		//  - For StateForfeited it's the Player who resigned
 		Player() Player
 		// PlayerAt returns the Player at the given Cell, where possible.
 		//
 		// An ErrOutOfBounds is returned if Cell is out-of-bounds.
 		PlayerAt(cell Cell) (Player, error)
+		// Rand returns the source of randomness used by Game and any built-in Bot.
+		//
+		// A custom Bot should also use it for any decisions based on randomness so that games can be reproduced (see
+		// WithSeed). It's only safe for concurrent use, except for its Read method, if Game was started using
+		// WithConcurrencySafety.
+		Rand() *rand.Rand
+		// Redo replays the last Turn reverted by Undo and returns the resulting State and Player.
+		//
+		// When playing against a Bot, any Turn taken by the Bot that was reverted along with the Turn of the human is
+		// also replayed.
+		//
+		// A reverted Turn can no longer be replayed once another Turn has been played.
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game has StateForfeited
+		//  - ErrNothingToRedo if there is no reverted Turn to be replayed
+		Redo() (State, Player, error)
 		// RemainingTurns returns the number of turns remaining
 		RemainingTurns() int
-		// Size returns the size of the Board
+		// Resign ends Game early with the given Player forfeiting and returns the resulting State and Player.
+		//
+		// Either Player can resign at any point while Game has StateAwaitingTurn, regardless of whose turn it is. Once
+		// resigned, Game has StateForfeited and the Player returned is the given Player who resigned.
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game doesn't have StateAwaitingTurn
+		//  - ErrPlayerNotFound if Player is invalid
+		Resign(player Player) (State, Player, error)
+		// Rows returns the number of rows on the Board
+		Rows() uint8
+		// Size returns the size of the Board.
+		//
+		// For a rectangular Board, the greater of the number of rows and columns is returned.
 		Size() uint8
+		// Snapshot returns a Snapshot of Game that can be used to restore it later using WithSnapshot
+		Snapshot() Snapshot
 		// State returns the current State
 		State() State
 		// String returns a classic ASCII representation of the Board
 		String() string
 		// Turns returns a copy of each Turn already played
 		Turns() []Turn
+		// WinningCells returns the Cells that resulted in the win, where possible.
+		//
+		// Under misère rules (see WithMisere), they are the Cells of the line completed by the losing Player.
+		//
+		// Nil is returned if Game does not have StateWon or none of its winning conditions implement
+		// WinningCellsCondition.
+		WinningCells() Cells
+		// Undo reverts the last Turn played and returns the resulting State and Player.
+		//
+		// When playing against a Bot, the last Turn of the human is also reverted along with any Turn since taken by the
+		// Bot so that it's the turn of the human once again.
+		//
+		// Any Turn derived from a Board passed to WithBoard cannot be reverted.
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game has StateForfeited
+		//  - ErrNothingToUndo if there is no Turn to be reverted
+		Undo() (State, Player, error)
+		// WinLength returns the number of consecutive cells in any row, column, or diagonal that a Player must occupy
+		// to win
+		WinLength() uint8
 	}
 
 	game struct {
-		board      Board
-		bot        Bot
-		conditions Conditions
-		maxTurns   int
-		player     Player
-		size       uint8
-		state      State
-		turns      []Turn
+		board        Board
+		boardTurns   int
+		bots         map[Player]Bot
+		cols         uint8
+		conditions   Conditions
+		gravity      bool
+		maxTurns     int
+		misere       bool
+		observers    []Observer
+		safe         bool
+		player       Player
+		rand         *rand.Rand
+		restore      *Snapshot
+		rows         uint8
+		starter      Player
+		state        State
+		turns        []Turn
+		undone       []Turn
+		winLength    uint8
+		winningCells Cells
 	}
 )
 
 func (g *game) AllowBotTurn() (State, Player, error) {
+	return g.AllowBotTurnContext(context.Background())
+}
+
+func (g *game) AllowBotTurnContext(ctx context.Context) (State, Player, error) {
 	if !g.IsBotTurn() {
 		return g.state, g.player, nil
 	}
-	cell, err := g.bot.Turn(g.board, g)
-	if err != nil {
-		return g.state, g.player, fmtBotErr(g.bot, err)
-	}
-	_, _, err = g.play(Turn{Cell: cell, Player: g.player}, true)
-	if err != nil {
-		err = fmtBotErr(g.bot, err)
-	}
-	return g.state, g.player, err
+	bot := g.bots[g.player]
+	g.notify(func(o Observer) {
+		o.BotTurnStarted(g, bot)
+	})
+	cell, err := botTurn(ctx, bot, g.board, g)
+	return g.playBotTurn(bot, cell, err)
 }
 
 func (g *game) Board() Board {
 	return g.board.Copy()
 }
 
+func (g *game) Bot(player Player) Bot {
+	return g.bots[player]
+}
+
+func (g *game) Columns() uint8 {
+	return g.cols
+}
+
 func (g *game) Conditions() Conditions {
 	return g.conditions[:]
 }
 
+func (g *game) HasGravity() bool {
+	return g.gravity
+}
+
 func (g *game) IsBotTurn() bool {
-	return g.state == StateAwaitingTurn && g.bot != nil && g.bot.Player() == g.player
+	return g.state == StateAwaitingTurn && g.bots[g.player] != nil
+}
+
+func (g *game) IsMisere() bool {
+	return g.misere
 }
 
 func (g *game) LastTurn() (Turn, bool) {
@@ -511,6 +756,17 @@ func (g *game) LastTurn() (Turn, bool) {
 	}
 }
 
+func (g *game) LegalCells() Cells {
+	if g.state != StateAwaitingTurn {
+		return nil
+	}
+	return g.board.findLegal(g.gravity)
+}
+
+func (g *game) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Snapshot())
+}
+
 func (g *game) MaxTurns() int {
 	return g.maxTurns
 }
@@ -530,12 +786,69 @@ func (g *game) PlayerAt(cell Cell) (Player, error) {
 	return g.board[cell.Row][cell.Column], nil
 }
 
+func (g *game) Rand() *rand.Rand {
+	return g.rand
+}
+
+func (g *game) Redo() (State, Player, error) {
+	if g.state == StateForfeited {
+		return g.state, g.player, ErrGameOver
+	}
+	if len(g.undone) == 0 {
+		return g.state, g.player, ErrNothingToRedo
+	}
+	g.redo()
+	if l := len(g.undone); l > 0 && g.IsBotTurn() && g.undone[l-1].Player == g.player {
+		g.redo()
+	}
+	return g.state, g.player, nil
+}
+
 func (g *game) RemainingTurns() int {
 	return g.maxTurns - len(g.turns)
 }
 
+func (g *game) Resign(player Player) (State, Player, error) {
+	err := g.resign(player)
+	return g.state, g.player, err
+}
+
+func (g *game) Rows() uint8 {
+	return g.rows
+}
+
 func (g *game) Size() uint8 {
-	return g.size
+	return max(g.rows, g.cols)
+}
+
+func (g *game) Snapshot() Snapshot {
+	s := Snapshot{
+		Version:   SnapshotVersion,
+		Rows:      g.rows,
+		Columns:   g.cols,
+		WinLength: g.winLength,
+		Gravity:   g.gravity,
+		Misere:    g.misere,
+		Starter:   g.starter,
+		State:     g.state,
+		Player:    g.player,
+		Turns:     append([]Turn{}, g.turns[g.boardTurns:]...),
+	}
+	if g.boardTurns > 0 {
+		s.Board = g.board.Copy()
+		for _, turn := range s.Turns {
+			s.Board[turn.Row][turn.Column] = 0
+		}
+	}
+	for _, player := range Players() {
+		if bot := g.bots[player]; bot != nil {
+			s.Bots = append(s.Bots, SnapshotBot{
+				Name:   bot.Name(),
+				Player: player,
+			})
+		}
+	}
+	return s
 }
 
 func (g *game) State() State {
@@ -547,22 +860,42 @@ func (g *game) String() string {
 }
 
 func (g *game) Turns() []Turn {
-	return g.turns[:]
+	return append([]Turn(nil), g.turns...)
 }
 
-func (g *game) play(turn Turn, allowBotTurn bool) (State, Player, error) {
-	if err := g.validateBounds(turn.Cell); err != nil {
-		return g.state, g.player, err
+func (g *game) Undo() (State, Player, error) {
+	if g.state == StateForfeited {
+		return g.state, g.player, ErrGameOver
 	}
-	if err := g.validateTurn(turn, allowBotTurn); err != nil {
-		return g.state, g.player, err
+	if len(g.turns) <= g.boardTurns {
+		return g.state, g.player, ErrNothingToUndo
+	}
+	g.undo()
+	if len(g.turns) > g.boardTurns && g.IsBotTurn() {
+		g.undo()
 	}
+	return g.state, g.player, nil
+}
+
+func (g *game) WinLength() uint8 {
+	return g.winLength
+}
 
+func (g *game) WinningCells() Cells {
+	return append(Cells(nil), g.winningCells...)
+}
+
+func (g *game) apply(turn Turn) {
+	state := g.state
 	g.board[turn.Row][turn.Column] = turn.Player
 	g.turns = append(g.turns, turn)
 
 	if g.conditions.IsWinningTurn(g.board, turn) {
 		g.state = StateWon
+		g.winningCells = g.conditions.WinningCells(g.board, turn)
+		if g.misere {
+			g.player = turn.Player.Next()
+		}
 	} else if len(g.turns) >= g.maxTurns {
 		g.player = 0
 		g.state = StateDraw
@@ -570,15 +903,124 @@ func (g *game) play(turn Turn, allowBotTurn bool) (State, Player, error) {
 		g.player = turn.Player.Next()
 	}
 
+	g.notify(func(o Observer) {
+		o.TurnPlayed(g, turn)
+	})
+	g.notifyStateChanged(state)
+}
+
+func (g *game) play(turn Turn, allowBotTurn bool) (State, Player, error) {
+	if err := g.validateBounds(turn.Cell); err != nil {
+		return g.state, g.player, err
+	}
+	if err := g.validateTurn(turn, allowBotTurn); err != nil {
+		return g.state, g.player, err
+	}
+
+	g.undone = nil
+	g.apply(turn)
+
 	return g.state, g.player, nil
 }
 
+func (g *game) applySnapshot(snapshot Snapshot) error {
+	for i, turn := range snapshot.Turns {
+		if _, _, err := g.play(turn, true); err != nil {
+			return fmt.Errorf("turn[%d]: %w", i, err)
+		}
+	}
+	if snapshot.State == StateForfeited {
+		if err := g.resign(snapshot.Player); err != nil {
+			return fmt.Errorf("resign: %w", err)
+		}
+	}
+	if g.state != snapshot.State || g.player != snapshot.Player {
+		return fmt.Errorf("snapshot state (%v, %d) does not match state of turns (%v, %d)", snapshot.State,
+			snapshot.Player, g.state, g.player)
+	}
+	return nil
+}
+
+func (g *game) notify(fn func(o Observer)) {
+	if g.restore != nil {
+		return
+	}
+	for _, o := range g.observers {
+		fn(o)
+	}
+}
+
+func (g *game) notifyStateChanged(from State) {
+	if from == g.state {
+		return
+	}
+	g.notify(func(o Observer) {
+		o.StateChanged(g, from, g.state)
+	})
+	if g.state != StateAwaitingTurn {
+		g.notify(func(o Observer) {
+			o.GameOver(g, g.state, g.player)
+		})
+	}
+}
+
+// playBotTurn plays the Cell chosen by the given Bot for the current Player, unless the Bot failed to take its turn.
+//
+// Bot must be the one that was asked to take the turn rather than the one currently controlling the Player, which may
+// no longer be the same if Game was changed while it took its turn.
+func (g *game) playBotTurn(bot Bot, cell Cell, err error) (State, Player, error) {
+	g.notify(func(o Observer) {
+		o.BotTurnFinished(g, bot, cell, err)
+	})
+	if err != nil {
+		return g.state, g.player, fmtBotErr(bot, err)
+	}
+	_, _, err = g.play(Turn{Cell: cell, Player: g.player}, true)
+	if err != nil {
+		err = fmtBotErr(bot, err)
+	}
+	return g.state, g.player, err
+}
+
+func (g *game) redo() {
+	l := len(g.undone)
+	turn := g.undone[l-1]
+	g.undone = g.undone[:l-1]
+	g.apply(turn)
+}
+
+func (g *game) resign(player Player) error {
+	if g.state != StateAwaitingTurn {
+		return ErrGameOver
+	}
+	if !player.IsValid() {
+		return fmtPlayerNotFoundErr(player)
+	}
+	g.player = player
+	g.state = StateForfeited
+	g.notifyStateChanged(StateAwaitingTurn)
+	return nil
+}
+
+func (g *game) undo() {
+	l := len(g.turns)
+	turn := g.turns[l-1]
+	g.board[turn.Row][turn.Column] = 0
+	g.turns = g.turns[:l-1]
+	g.undone = append(g.undone, turn)
+	g.player = turn.Player
+	state := g.state
+	g.state = StateAwaitingTurn
+	g.winningCells = nil
+	g.notifyStateChanged(state)
+}
+
 func (g *game) validateBounds(cell Cell) error {
-	if cell.Row >= g.size {
-		return fmtRowOutOfBoundsErr(cell, g.size)
+	if cell.Row >= g.rows {
+		return fmtRowOutOfBoundsErr(cell, g.rows)
 	}
-	if cell.Column >= g.size {
-		return fmtColOutOfBoundsErr(cell, g.size)
+	if cell.Column >= g.cols {
+		return fmtColOutOfBoundsErr(cell, g.cols)
 	}
 	return nil
 }
@@ -591,7 +1033,7 @@ func (g *game) validateTurn(turn Turn, allowBotTurn bool) error {
 	if !player.IsValid() {
 		return fmtPlayerNotFoundErr(player)
 	}
-	if g.bot != nil && g.bot.Player() == player && !allowBotTurn {
+	if g.bots[player] != nil && !allowBotTurn {
 		return fmtInvalidTurnErr(fmt.Sprintf("human cannot play turn for bot player[%d]", player))
 	}
 	if player != g.player {
@@ -601,6 +1043,12 @@ func (g *game) validateTurn(turn Turn, allowBotTurn bool) error {
 	if existing := g.board[row][col]; existing > 0 {
 		return fmtInvalidTurnErr(fmt.Sprintf("cell[%d,%d] already taken by player %d", row, col, existing))
 	}
+	if g.gravity {
+		if lowest, _ := g.board.FindLowestEmpty(col); lowest.Row != row {
+			return fmtInvalidTurnErr(fmt.Sprintf("cell[%d,%d] is above lowest empty cell[%d,%d]", row, col, lowest.Row,
+				col))
+		}
+	}
 	return nil
 }
 
@@ -621,10 +1069,10 @@ func MustStart(opts ...Option) Game {
 //   - ErrOptionInvalid if an Option is passed that was given an invalid argument
 func Start(opts ...Option) (Game, error) {
 	g := &game{
-		maxTurns:   int(MinSize) * int(MinSize),
-		conditions: standardConditions[:],
-		size:       MinSize,
-		state:      StateAwaitingTurn,
+		cols:  MinSize,
+		rand:  rand.New(rand.NewSource(rand.Int63())),
+		rows:  MinSize,
+		state: StateAwaitingTurn,
 	}
 
 	for _, opt := range opts {
@@ -633,12 +1081,29 @@ func Start(opts ...Option) (Game, error) {
 		}
 	}
 
-	if g.bot != nil && g.size > g.bot.MaxSize() {
-		return nil, fmtBotMaxSizeExceededErr(g.bot)
+	g.maxTurns = int(g.rows) * int(g.cols)
+	if g.winLength == 0 {
+		g.winLength = min(g.rows, g.cols)
+	} else if size := g.Size(); g.winLength > size {
+		return nil, fmtInvalidOptionErr("WithWinLength", fmt.Errorf("win length must be at most board size: %d", size))
+	}
+	g.conditions = append(newStandardConditions(g.winLength), g.conditions...)
+
+	for _, player := range Players() {
+		if bot := g.bots[player]; bot != nil && g.Size() > bot.MaxSize() {
+			return nil, fmtBotMaxSizeExceededErr(bot)
+		}
+	}
+
+	if g.board != nil && g.gravity {
+		if cell, floating := g.board.findFloating(); floating {
+			return nil, fmtInvalidOptionErr("WithGravity", fmt.Errorf("board cell[%d,%d] is above empty cell", cell.Row,
+				cell.Column))
+		}
 	}
 
 	if g.board == nil {
-		g.board = newBoard(g.size)
+		g.board = NewBoard(g.rows, g.cols)
 		if g.player == 0 {
 			g.player = PlayerOne
 		}
@@ -647,6 +1112,18 @@ func Start(opts ...Option) (Game, error) {
 	} else if winner > 0 {
 		g.state = StateWon
 		g.player = winner
+		if g.misere {
+			g.player = winner.Next()
+		}
+		for _, turn := range g.turns {
+			if turn.Player == winner {
+				for _, cell := range g.conditions.WinningCells(g.board, turn) {
+					if !slices.Contains(g.winningCells, cell) {
+						g.winningCells = append(g.winningCells, cell)
+					}
+				}
+			}
+		}
 	} else if len(g.turns) >= g.maxTurns {
 		g.state = StateDraw
 		g.player = 0
@@ -654,6 +1131,21 @@ func Start(opts ...Option) (Game, error) {
 		g.player = PlayerOne
 	}
 
+	if g.state == StateAwaitingTurn {
+		g.starter = g.player
+	}
+	if g.restore != nil {
+		if err := g.applySnapshot(*g.restore); err != nil {
+			return nil, fmtInvalidOptionErr("WithSnapshot", err)
+		}
+		g.restore = nil
+	}
+
+	if g.safe {
+		// Bots use the source of randomness without holding the lock while taking their turn
+		g.rand = random.NewLocked(g.rand)
+		return &syncGame{game: g}, nil
+	}
 	return g, nil
 }
 
@@ -669,7 +1161,7 @@ type (
 //
 // The following game parameters are derived from board if valid:
 //   - Player (e.g. starter, winner), where possible
-//   - Size
+//   - Size (i.e. rows and columns)
 //   - State
 //   - Turns
 //
@@ -677,37 +1169,70 @@ type (
 // "correct" starting player cannot be derived from Board. Any options that register one or more additional winning
 // Condition will be honored when checking whether board has been won.
 //
+// This option is ignored if preceded by WithSnapshot.
+//
 // An ErrOptionInvalid is returned by the option if board is invalid. For example;
 //   - Length is not within the valid range (i.e. MinSize, MaxSize)
-//   - Contains row with number of columns not equaling length of board
+//   - Contains row with number of columns not within the valid range (i.e. MinSize, MaxSize)
+//   - Contains row with number of columns not equaling that of the first row
 //   - Contains cell with an invalid non-zero Player
 //   - Either Player has an unfair advantage (i.e. more than one turn ahead of the other)
 func WithBoard(board Board) Option {
 	return func(g *game) error {
-		starter, size, maxTurns, turns, err := board.check()
-		if err != nil {
-			return fmtInvalidOptionErr("WithBoard", err)
+		if g.restore != nil {
+			return nil
 		}
-		g.board = board
-		g.maxTurns = maxTurns
-		g.size = size
-		g.turns = turns
-		if starter > 0 {
-			g.player = starter
+		if err := setBoard(g, board); err != nil {
+			return fmtInvalidOptionErr("WithBoard", err)
 		}
 		return nil
 	}
 }
 
-// WithBot customizes a Game to play against the given Bot.
+// WithBot customizes a Game so that the Player of the given Bot is controlled by it.
+//
+// A Game can have a Bot for each Player, allowing two bots to play each other (see WithBots).
 //
-// This option is ignored if preceded by another bot-controlling option (e.g. WithNormalBot).
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithNormalBot).
 //
 // An ErrOptionInvalid is returned by the option if bot has an invalid Player.
 func WithBot(bot Bot) Option {
 	return withBot(bot, "WithBot")
 }
 
+// WithBots customizes a Game so that the Player of each of the given bots is controlled by it, typically used to have
+// two bots play each other.
+//
+// Each Bot is ignored if preceded by another bot-controlling option for the same Player (e.g. WithNormalBot), including
+// an earlier Bot passed to WithBots.
+//
+// An ErrOptionInvalid is returned by the option if any Bot has an invalid Player.
+func WithBots(bots ...Bot) Option {
+	return func(g *game) error {
+		for _, bot := range bots {
+			if err := withBot(bot, "WithBots")(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// WithConcurrencySafety customizes a Game so that it's safe for concurrent use by multiple goroutines.
+//
+// All methods of the Game are guarded by a lock, except that the lock is not held while a Bot is checking the Board for
+// its turn so that the Game can still be read (e.g. to render the Board) in the meantime. If the Game is modified before
+// the Bot finishes, its turn is discarded and AllowBotTurn returns an ErrBot.
+//
+// Any Observer is notified while the lock is held, so it must only use the Game passed to it and never the Game
+// returned by Start.
+func WithConcurrencySafety() Option {
+	return func(g *game) error {
+		g.safe = true
+		return nil
+	}
+}
+
 // WithCondition customizes a Game to include an additional winning Condition
 func WithCondition(condition Condition) Option {
 	return func(g *game) error {
@@ -724,18 +1249,61 @@ func WithConditions(conditions Conditions) Option {
 	}
 }
 
+// WithDimensions customizes a Game to create a Board with the given number of rows and columns, allowing for rectangular
+// boards.
+//
+// This option is ignored if preceded by another size-controlling option (e.g. WithSize) or if WithBoard is also used.
+//
+// An ErrOptionInvalid is returned by the option if either rows or cols is not within the valid range (i.e. MinSize,
+// MaxSize).
+func WithDimensions(rows, cols uint8) Option {
+	return func(g *game) error {
+		if g.board != nil {
+			return nil
+		}
+		if rows < MinSize || cols < MinSize {
+			return fmtInvalidOptionErr("WithDimensions", fmt.Errorf("rows and columns must be at least: %d", MinSize))
+		}
+		if rows > MaxSize || cols > MaxSize {
+			return fmtInvalidOptionErr("WithDimensions", fmt.Errorf("rows and columns must be at most: %d", MaxSize))
+		}
+		g.cols = cols
+		g.rows = rows
+		return nil
+	}
+}
+
 // WithEasyBot is a convenient shorthand for WithBot(NewEasyBot(player)).
 //
-// This option is ignored if preceded by another bot-controlling option (e.g. WithNormalBot).
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithNormalBot).
 //
 // An ErrOptionInvalid is returned by the option if player is invalid.
 func WithEasyBot(player Player) Option {
 	return withBot(NewEasyBot(player), "WithEasyBot")
 }
 
+// WithGravity customizes a Game to be played with gravity (e.g. Connect Four-style play), where a turn is effectively
+// taken by choosing a column and can only be taken in the lowest empty Cell of that column (see Board.FindLowestEmpty).
+// This works with any rectangular Board and win length, while all built-in bots, as well as Analyze, only consider such
+// cells (see Game.LegalCells).
+//
+// Where a Board is also passed using WithBoard, or a position using WithPosition, Start returns an ErrOptionInvalid if
+// any cell contains a Player while the cell below it does not.
+//
+// This option is ignored if preceded by WithSnapshot.
+func WithGravity() Option {
+	return func(g *game) error {
+		if g.restore != nil {
+			return nil
+		}
+		g.gravity = true
+		return nil
+	}
+}
+
 // WithHardBot is a convenient shorthand for WithBot(NewHardBot(player)).
 //
-// This option is ignored if preceded by another bot-controlling option (e.g. WithNormalBot).
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithNormalBot).
 //
 // An ErrOptionInvalid is returned by the option if player is invalid.
 func WithHardBot(player Player) Option {
@@ -744,22 +1312,57 @@ func WithHardBot(player Player) Option {
 
 // WithImpossibleBot is a convenient shorthand for WithBot(NewImpossibleBot(player)).
 //
-// This option is ignored if preceded by another bot-controlling option (e.g. WithNormalBot).
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithNormalBot).
 //
 // An ErrOptionInvalid is returned by the option if player is invalid.
 func WithImpossibleBot(player Player) Option {
 	return withBot(NewImpossibleBot(player), "WithImpossibleBot")
 }
 
+// WithMCTSBot is a convenient shorthand for WithBot(NewMCTSBot(player, budget)).
+//
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithNormalBot).
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithMCTSBot(player Player, budget MCTSBudget) Option {
+	return withBot(NewMCTSBot(player, budget), "WithMCTSBot")
+}
+
+// WithMisere customizes a Game to be played under misère rules, where the Player whose Turn completes a line (i.e.
+// satisfies any winning Condition) loses and their opponent is declared the winner.
+//
+// All built-in bots, as well as Analyze, adapt their play accordingly.
+//
+// This option is ignored if preceded by WithSnapshot.
+func WithMisere() Option {
+	return func(g *game) error {
+		if g.restore != nil {
+			return nil
+		}
+		g.misere = true
+		return nil
+	}
+}
+
 // WithNormalBot is a convenient shorthand for WithBot(NewNormalBot(player)).
 //
-// This option is ignored if preceded by another bot-controlling option (e.g. WithHardBot).
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithHardBot).
 //
 // An ErrOptionInvalid is returned by the option if player is invalid.
 func WithNormalBot(player Player) Option {
 	return withBot(NewNormalBot(player), "WithNormalBot")
 }
 
+// WithObserver customizes a Game to notify the given Observer of any events that occur within it.
+//
+// This option can be used more than once, with each Observer being notified in the order in which they were passed.
+func WithObserver(observer Observer) Option {
+	return func(g *game) error {
+		g.observers = append(g.observers, observer)
+		return nil
+	}
+}
+
 // WithPack customizes a Game by applying the given Pack
 func WithPack(pack Pack) Option {
 	return func(g *game) error {
@@ -772,6 +1375,51 @@ func WithPack(pack Pack) Option {
 	}
 }
 
+// WithPosition customizes a Game to start from the position represented by the given compact notation (see Format).
+//
+// The Board, starting Player, and win length, where included, are derived from notation. Otherwise, this option behaves
+// the same as WithBoard and is also ignored if preceded by WithSnapshot.
+//
+// An ErrOptionInvalid, also wrapping ErrNotationInvalid, is returned by the option if notation is malformed or
+// represents an invalid position.
+func WithPosition(notation string) Option {
+	return func(g *game) error {
+		if g.restore != nil {
+			return nil
+		}
+		p, err := parsePosition(notation)
+		if err != nil {
+			return fmtInvalidOptionErr("WithPosition", fmtInvalidNotationErr(err))
+		}
+		if err = setBoard(g, p.board); err != nil {
+			return fmtInvalidOptionErr("WithPosition", err)
+		}
+		if p.player > 0 {
+			g.player = p.player
+		}
+		if p.winLength > 0 {
+			g.winLength = p.winLength
+		}
+		return nil
+	}
+}
+
+// WithRand customizes a Game to use the given source of randomness, both while it's being started and by any built-in
+// Bot.
+//
+// This option must precede any option that relies on randomness (e.g. WithRandomSize) for them to use r.
+//
+// An ErrOptionInvalid is returned by the option if r is nil.
+func WithRand(r *rand.Rand) Option {
+	return func(g *game) error {
+		if r == nil {
+			return fmtInvalidOptionErr("WithRand", errors.New("rand is nil"))
+		}
+		g.rand = r
+		return nil
+	}
+}
+
 // WithRandomSize customizes a Game to create a Board with a random size.
 //
 // This option is ignored if preceded by another size-controlling option (e.g. WithSize) or if WithBoard is also used.
@@ -780,9 +1428,9 @@ func WithRandomSize() Option {
 		if g.board != nil {
 			return nil
 		}
-		size := rand.Intn(int(MaxSize)-int(MinSize)) + int(MinSize)
-		g.maxTurns = size * size
-		g.size = uint8(size)
+		size := uint8(g.rand.Intn(int(MaxSize)-int(MinSize)) + int(MinSize))
+		g.cols = size
+		g.rows = size
 		return nil
 	}
 }
@@ -794,12 +1442,25 @@ func WithRandomSize() Option {
 func WithRandomStarterPlayer() Option {
 	return func(g *game) error {
 		if g.player == 0 {
-			g.player = Player(rand.Intn(2) + 1)
+			g.player = Player(g.rand.Intn(2) + 1)
 		}
 		return nil
 	}
 }
 
+// WithSeed customizes a Game to use a source of randomness with the given seed, both while it's being started and by any
+// built-in Bot, so that the same random decisions are made whenever the same seed is used. This makes it possible to
+// reproduce a game exactly, provided that the same turns are played and no Bot is limited by time (e.g. an MCTSBudget
+// with a Duration).
+//
+// This option must precede any option that relies on randomness (e.g. WithRandomSize) for them to use the seed.
+func WithSeed(seed int64) Option {
+	return func(g *game) error {
+		g.rand = rand.New(rand.NewSource(seed))
+		return nil
+	}
+}
+
 // WithSize customizes a Game to create a Board with the given size.
 //
 // This option is ignored if preceded by another size-controlling option (e.g. WithRandomStarterPlayer) or if WithBoard
@@ -817,8 +1478,8 @@ func WithSize(size uint8) Option {
 		if size > MaxSize {
 			return fmtInvalidOptionErr("WithSize", fmt.Errorf("size must be at most: %d", MaxSize))
 		}
-		g.maxTurns = int(size) * int(size)
-		g.size = size
+		g.cols = size
+		g.rows = size
 		return nil
 	}
 }
@@ -842,15 +1503,83 @@ func WithStarterPlayer(player Player) Option {
 	}
 }
 
-func withBot(bot Bot, option string) Option {
+// WithSnapshot customizes a Game to restore the given Snapshot, typically taken from another Game using Game.Snapshot.
+//
+// The Board, win length, gravity, misère rules, starting Player, and bots are derived from snapshot before each of its
+// turns is played in order. This option takes precedence over WithBoard, WithGravity, and WithMisere as well as any
+// size-controlling, player-controlling, and win-length-controlling options.
+//
+// A Bot within snapshot is only restored automatically if it's a built-in or registered Bot (see RegisterBot). Any
+// other Bot must be passed using a preceding bot-controlling option (e.g. WithBot). Likewise, any additional winning
+// Condition used by the original Game must be passed again using WithCondition or WithConditions.
+//
+// An ErrOptionInvalid is returned if snapshot is invalid. For example;
+//   - Version is not supported (i.e. less than 1 or greater than SnapshotVersion)
+//   - Board, rows, columns, or win length are invalid or inconsistent
+//   - Any Bot is unknown or does not match that of a preceding bot-controlling option for the same Player
+//   - Any Turn cannot be played
+//   - State or Player does not match the result of playing all turns
+func WithSnapshot(snapshot Snapshot) Option {
 	return func(g *game) error {
-		if g.bot != nil {
+		if err := setSnapshot(g, snapshot); err != nil {
+			return fmtInvalidOptionErr("WithSnapshot", err)
+		}
+		return nil
+	}
+}
+
+// WithWinLength customizes a Game to only require a Player to occupy the given number of consecutive cells in any row,
+// column, or diagonal to win (e.g. 5 for Gomoku-style play).
+//
+// By default, a Player must occupy an entire row, column, or main diagonal to win (i.e. the win length is the size of
+// the Board). For a rectangular Board, the win length defaults to the lesser of the number of rows and columns.
+//
+// This option is ignored if preceded by WithSnapshot.
+//
+// An ErrOptionInvalid is returned by the option if length is less than MinWinLength or if it's greater than the size of
+// the Board.
+func WithWinLength(length uint8) Option {
+	return func(g *game) error {
+		if g.restore != nil {
 			return nil
 		}
-		if player := bot.Player(); !player.IsValid() {
+		if length < MinWinLength {
+			return fmtInvalidOptionErr("WithWinLength", fmt.Errorf("win length must be at least: %d", MinWinLength))
+		}
+		g.winLength = length
+		return nil
+	}
+}
+
+func setBoard(g *game, board Board) error {
+	starter, rows, cols, turns, err := board.check()
+	if err != nil {
+		return err
+	}
+	g.board = board
+	g.boardTurns = len(turns)
+	g.cols = cols
+	g.rows = rows
+	g.turns = turns
+	if starter > 0 {
+		g.player = starter
+	}
+	return nil
+}
+
+func withBot(bot Bot, option string) Option {
+	return func(g *game) error {
+		player := bot.Player()
+		if !player.IsValid() {
 			return fmtInvalidOptionErr(option, fmtPlayerNotFoundErr(player))
 		}
-		g.bot = bot
+		if g.bots[player] != nil {
+			return nil
+		}
+		if g.bots == nil {
+			g.bots = make(map[Player]Bot, 2)
+		}
+		g.bots[player] = bot
 		return nil
 	}
 }
@@ -924,7 +1653,7 @@ type Turn struct {
 	// Cell is the location of the cell on the Board
 	Cell
 	// Player is the Player
-	Player Player
+	Player Player `json:"player"`
 }
 
 // State represents the state of a Game
@@ -937,12 +1666,14 @@ const (
 	StateDraw
 	// StateWon represents the state in which the game is over with a clear winner
 	StateWon
+	// StateForfeited represents the state in which the game is over due to a player having resigned
+	StateForfeited
 )
 
 // IsValid returns whether State is valid
 func (s State) IsValid() bool {
 	switch s {
-	case StateAwaitingTurn, StateDraw, StateWon:
+	case StateAwaitingTurn, StateDraw, StateWon, StateForfeited:
 		return true
 	default:
 		return false
@@ -958,6 +1689,8 @@ func (s State) String() string {
 		return "Draw"
 	case StateWon:
 		return "Won"
+	case StateForfeited:
+		return "Forfeited"
 	default:
 		return fmt.Sprintf("Unknown State (%d)", s)
 	}
@@ -965,5 +1698,5 @@ func (s State) String() string {
 
 // States returns valid State values
 func States() []State {
-	return []State{StateAwaitingTurn, StateDraw, StateWon}
+	return []State{StateAwaitingTurn, StateDraw, StateWon, StateForfeited}
 }
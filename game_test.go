@@ -0,0 +1,384 @@
+package tictactoe
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLineConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		board      string
+		length     uint8
+		dRow, dCol int
+		turn       string
+		winner     Player
+		cells      []string
+	}{
+		{"row", "xxx/oo./...", 3, 0, 1, "b1", PlayerOne, []string{"a1", "b1", "c1"}},
+		{"row k < size", "..../.ooo/xx../x...", 3, 0, 1, "d2", PlayerTwo, []string{"b2", "c2", "d2"}},
+		{"column rectangular", "x../x../x../oo.", 3, 1, 0, "a2", PlayerOne, []string{"a1", "a2", "a3"}},
+		{"column k < size", "...o./.x.o./...o./.x..x", 3, 1, 0, "d1", PlayerTwo, []string{"d1", "d2", "d3"}},
+		{"diagonal", ".x../..x./...x/oo..", 3, 1, 1, "c2", PlayerOne, []string{"b1", "c2", "d3"}},
+		{"diagonal longer than k", "x.../.x../..x./o..x/oo..", 3, 1, 1, "b2", PlayerOne,
+			[]string{"a1", "b2", "c3", "d4"}},
+		{"anti-diagonal rectangular", "....x/...x./..xoo", 3, 1, -1, "d2", PlayerOne, []string{"e1", "d2", "c3"}},
+		{"anti-diagonal k < size", "x.../x..o/x.o./.o..", 3, 1, -1, "b4", PlayerTwo, []string{"d2", "c3", "b4"}},
+		{"row k - 1", "xx../o.o./....", 3, 0, 1, "b1", 0, nil},
+		{"row broken by opponent", "xxox/.o../.o..", 3, 0, 1, "d1", 0, nil},
+		{"column k - 1", "o../o../.x./.x./...", 3, 1, 0, "a2", 0, nil},
+		{"column broken by opponent", "x.o/x.o/o../x../x.o", 3, 1, 0, "a4", 0, nil},
+		{"diagonal k - 1", "x...o/.x.o./.....", 3, 1, 1, "b2", 0, nil},
+		{"diagonal broken by opponent", "x..o/.o../..x./o..x", 3, 1, 1, "d4", 0, nil},
+		{"anti-diagonal k - 1", "...x/..x./..../o..o", 3, 1, -1, "c2", 0, nil},
+		{"anti-diagonal broken by opponent", "o..x/..o./.x../x..o", 3, 1, -1, "b3", 0, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			board, err := ParseBoard(tc.board)
+			if err != nil {
+				t.Fatalf("ParseBoard() returned unexpected error: %v", err)
+			}
+			cell := mustParseCell(t, tc.turn)
+			turn := Turn{Cell: cell, Player: board[cell.Row][cell.Column]}
+
+			if winner := findLineWinner(board, tc.length, tc.dRow, tc.dCol); winner != tc.winner {
+				t.Errorf("findLineWinner() = %v, want %v", winner, tc.winner)
+			}
+			if won := isLineWinningTurn(board, turn, tc.length, tc.dRow, tc.dCol); won != (tc.winner > 0) {
+				t.Errorf("isLineWinningTurn() = %v, want %v", won, tc.winner > 0)
+			}
+			var want Cells
+			for _, coords := range tc.cells {
+				want = append(want, mustParseCell(t, coords))
+			}
+			if cells := findLineWinningCells(board, turn, tc.length, tc.dRow, tc.dCol); !slices.Equal(cells, want) {
+				t.Errorf("findLineWinningCells() = %v, want %v", cells, want)
+			}
+		})
+	}
+}
+
+func TestGame_Play_Gravity(t *testing.T) {
+	g := MustStart(WithGravity())
+	for _, tc := range []struct {
+		coords string
+		want   error
+	}{
+		{"a1", ErrTurnInvalid},
+		{"a2", ErrTurnInvalid},
+		{"a3", nil},
+		{"a3", ErrTurnInvalid},
+		{"a1", ErrTurnInvalid},
+		{"a2", nil},
+	} {
+		if _, _, err := g.Play(Turn{Cell: mustParseCell(t, tc.coords), Player: g.Player()}); !errors.Is(err, tc.want) {
+			t.Errorf("Play() for %s returned error %v, want %v", tc.coords, err, tc.want)
+		}
+	}
+	if got := len(g.Turns()); got != 2 {
+		t.Errorf("Play() resulted in %d turns, want 2", got)
+	}
+}
+
+func TestGame_LegalCells_Gravity(t *testing.T) {
+	g := MustStart(WithGravity())
+	for _, tc := range []struct {
+		coords string
+		cells  []string
+	}{
+		{"a3", []string{"a2", "b3", "c3"}},
+		{"b3", []string{"a2", "b2", "c3"}},
+		{"a2", []string{"a1", "b2", "c3"}},
+		{"a1", []string{"b2", "c3"}},
+	} {
+		mustPlay(t, g, tc.coords)
+		var want Cells
+		for _, coords := range tc.cells {
+			want = append(want, mustParseCell(t, coords))
+		}
+		if cells := g.LegalCells(); !slices.Equal(cells, want) {
+			t.Errorf("LegalCells() after %s = %v, want %v", tc.coords, cells, want)
+		}
+	}
+}
+
+func TestStart_GravityPosition(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		position string
+		want     error
+	}{
+		{"supported", ".../x../xo. o", nil},
+		{"floating", ".../x../.o. o", ErrOptionInvalid},
+		{"floating above gap", "x../.../.o. o", ErrOptionInvalid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, opts := range [][]Option{
+				{WithGravity(), WithPosition(tc.position)},
+				{WithPosition(tc.position), WithGravity()},
+			} {
+				if _, err := Start(opts...); !errors.Is(err, tc.want) {
+					t.Errorf("Start() returned error %v, want %v", err, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGame_Resign(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		player Player
+	}{
+		{"own turn", PlayerOne},
+		{"other player", PlayerTwo},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart()
+			state, player, err := g.Resign(tc.player)
+			if err != nil {
+				t.Fatalf("Resign() returned unexpected error: %v", err)
+			}
+			if state != StateForfeited || player != tc.player {
+				t.Errorf("Resign() = %v, %v, want %v, %v", state, player, StateForfeited, tc.player)
+			}
+			if g.State() != StateForfeited || g.Player() != tc.player {
+				t.Errorf("Resign() resulted in %v for player[%d], want %v for player[%d]", g.State(), g.Player(),
+					StateForfeited, tc.player)
+			}
+			if _, _, err = g.Resign(tc.player.Next()); !errors.Is(err, ErrGameOver) {
+				t.Errorf("Resign() returned error %v, want %v", err, ErrGameOver)
+			}
+		})
+	}
+}
+
+func TestGame_Resign_Invalid(t *testing.T) {
+	g := MustStart()
+	if _, _, err := g.Resign(3); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Resign() returned error %v, want %v", err, ErrPlayerNotFound)
+	}
+
+	for _, coords := range []string{"a1", "a2", "b1", "b2", "c1"} {
+		mustPlay(t, g, coords)
+	}
+	state, player, err := g.Resign(PlayerTwo)
+	if !errors.Is(err, ErrGameOver) {
+		t.Errorf("Resign() returned error %v, want %v", err, ErrGameOver)
+	}
+	if state != StateWon || player != PlayerOne {
+		t.Errorf("Resign() = %v, %v, want %v, %v", state, player, StateWon, PlayerOne)
+	}
+}
+
+func TestGame_Undo(t *testing.T) {
+	g := MustStart()
+	for _, coords := range []string{"a1", "b2", "c3"} {
+		mustPlay(t, g, coords)
+	}
+	for i := 0; i < 3; i++ {
+		if _, _, err := g.Undo(); err != nil {
+			t.Fatalf("Undo() returned unexpected error: %v", err)
+		}
+	}
+	if turns := g.Turns(); len(turns) != 0 {
+		t.Errorf("Undo() resulted in %d turns, want 0", len(turns))
+	}
+	if g.State() != StateAwaitingTurn || g.Player() != PlayerOne {
+		t.Errorf("Undo() resulted in %v for player[%d], want %v for player[%d]", g.State(), g.Player(),
+			StateAwaitingTurn, PlayerOne)
+	}
+	if board := g.Board(); len(board.FindEmpty()) != 9 {
+		t.Errorf("Undo() resulted in board %q, want empty board", FormatBoard(board))
+	}
+	if _, _, err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() returned error %v, want %v", err, ErrNothingToUndo)
+	}
+}
+
+func TestGame_Redo_ClearedByPlay(t *testing.T) {
+	g := MustStart()
+	mustPlay(t, g, "a1")
+	mustPlay(t, g, "b2")
+	if _, _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo() returned unexpected error: %v", err)
+	}
+	mustPlay(t, g, "c3")
+	if _, _, err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Redo() returned error %v, want %v", err, ErrNothingToRedo)
+	}
+	if got, want := g.Turns(), []Turn{
+		{Cell: mustParseCell(t, "a1"), Player: PlayerOne},
+		{Cell: mustParseCell(t, "c3"), Player: PlayerTwo},
+	}; !slices.Equal(got, want) {
+		t.Errorf("Turns() = %v, want %v", got, want)
+	}
+}
+
+func TestGame_Undo_GameOver(t *testing.T) {
+	g := MustStart()
+	for _, coords := range []string{"a1", "a2", "b1", "b2", "c1"} {
+		mustPlay(t, g, coords)
+	}
+	if g.State() != StateWon {
+		t.Fatalf("Play() resulted in %v, want %v", g.State(), StateWon)
+	}
+	winningCells := g.WinningCells()
+
+	state, player, err := g.Undo()
+	if err != nil {
+		t.Fatalf("Undo() returned unexpected error: %v", err)
+	}
+	if state != StateAwaitingTurn || player != PlayerOne {
+		t.Errorf("Undo() = %v, %v, want %v, %v", state, player, StateAwaitingTurn, PlayerOne)
+	}
+	if cells := g.WinningCells(); cells != nil {
+		t.Errorf("WinningCells() = %v, want nil", cells)
+	}
+
+	if state, player, err = g.Redo(); err != nil {
+		t.Fatalf("Redo() returned unexpected error: %v", err)
+	}
+	if state != StateWon || player != PlayerOne {
+		t.Errorf("Redo() = %v, %v, want %v, %v", state, player, StateWon, PlayerOne)
+	}
+	if cells := g.WinningCells(); !slices.Equal(cells, winningCells) {
+		t.Errorf("WinningCells() = %v, want %v", cells, winningCells)
+	}
+}
+
+func TestGame_UndoRedo_Bot(t *testing.T) {
+	g := MustStart(WithNormalBot(PlayerTwo), WithSeed(1))
+	mustPlay(t, g, "a1")
+	if _, _, err := g.AllowBotTurn(); err != nil {
+		t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+	}
+	turns := g.Turns()
+
+	// Turn of bot is reverted along with that of the human so that it's the turn of the human once again
+	state, player, err := g.Undo()
+	if err != nil {
+		t.Fatalf("Undo() returned unexpected error: %v", err)
+	}
+	if state != StateAwaitingTurn || player != PlayerOne {
+		t.Errorf("Undo() = %v, %v, want %v, %v", state, player, StateAwaitingTurn, PlayerOne)
+	}
+	if got := len(g.Turns()); got != 0 {
+		t.Errorf("Undo() resulted in %d turns, want 0", got)
+	}
+
+	// Turn of bot is replayed along with that of the human without the bot taking it again
+	if state, player, err = g.Redo(); err != nil {
+		t.Fatalf("Redo() returned unexpected error: %v", err)
+	}
+	if state != StateAwaitingTurn || player != PlayerOne {
+		t.Errorf("Redo() = %v, %v, want %v, %v", state, player, StateAwaitingTurn, PlayerOne)
+	}
+	if got := g.Turns(); !slices.Equal(got, turns) {
+		t.Errorf("Turns() = %v, want %v", got, turns)
+	}
+	if g.IsBotTurn() {
+		t.Error("IsBotTurn() = true, want false")
+	}
+}
+
+func TestStart_Dimensions(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		opts       []Option
+		rows, cols uint8
+		winLength  uint8
+		want       error
+	}{
+		{"3x5", []Option{WithDimensions(3, 5)}, 3, 5, 3, nil},
+		{"5x3", []Option{WithDimensions(5, 3)}, 5, 3, 3, nil},
+		{"win length greater than rows", []Option{WithDimensions(3, 5), WithWinLength(4)}, 3, 5, 4, nil},
+		{"win length greater than columns", []Option{WithDimensions(5, 3), WithWinLength(5)}, 5, 3, 5, nil},
+		{"win length greater than size", []Option{WithDimensions(3, 5), WithWinLength(6)}, 0, 0, 0, ErrOptionInvalid},
+		{"rows too small", []Option{WithDimensions(MinSize-1, 5)}, 0, 0, 0, ErrOptionInvalid},
+		{"columns too small", []Option{WithDimensions(5, MinSize-1)}, 0, 0, 0, ErrOptionInvalid},
+		{"bot max size exceeded by columns", []Option{WithDimensions(3, 5), WithImpossibleBot(PlayerTwo)}, 0, 0, 0,
+			ErrBotMaxSizeExceeded},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := Start(tc.opts...)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Start() returned error %v, want %v", err, tc.want)
+			}
+			if err != nil {
+				return
+			}
+			if g.Rows() != tc.rows || g.Columns() != tc.cols {
+				t.Errorf("Start() resulted in %dx%d board, want %dx%d", g.Rows(), g.Columns(), tc.rows, tc.cols)
+			}
+			if size := max(tc.rows, tc.cols); g.Size() != size {
+				t.Errorf("Size() = %d, want %d", g.Size(), size)
+			}
+			if g.WinLength() != tc.winLength {
+				t.Errorf("WinLength() = %d, want %d", g.WinLength(), tc.winLength)
+			}
+		})
+	}
+}
+
+func TestGame_Play_RectangularDraw(t *testing.T) {
+	g := MustStart(WithDimensions(3, 5), WithWinLength(4))
+	if g.MaxTurns() != 15 {
+		t.Errorf("MaxTurns() = %d, want 15", g.MaxTurns())
+	}
+	// Filling each column in turn alternates players along each row so that no Player can complete a line of 4
+	for _, col := range "abcde" {
+		for _, row := range "123" {
+			if g.State() != StateAwaitingTurn {
+				t.Fatalf("Play() resulted in %v before board was full:\n%s", g.State(), g)
+			}
+			mustPlay(t, g, string(col)+string(row))
+		}
+	}
+	if g.State() != StateDraw || g.Player() != 0 {
+		t.Errorf("Play() resulted in %v for player[%d], want %v for player[0]", g.State(), g.Player(), StateDraw)
+	}
+	if g.RemainingTurns() != 0 {
+		t.Errorf("RemainingTurns() = %d, want 0", g.RemainingTurns())
+	}
+}
+
+func TestGame_WinningCells(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		position string
+		opts     []Option
+		turn     string
+		cells    []string
+	}{
+		{"single line", "xx./oo./... x", nil, "c1", []string{"a1", "b1", "c1"}},
+		{"two lines", ".xx/xoo/xoo x", nil, "a1", []string{"a1", "b1", "c1", "a2", "a3"}},
+		{"custom condition", "x.x/oo./... x", []Option{WithCondition(cornersCondition{})}, "a3", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(append([]Option{WithPosition(tc.position)}, tc.opts...)...)
+			mustPlay(t, g, tc.turn)
+			if g.State() != StateWon || g.Player() != PlayerOne {
+				t.Fatalf("Play() resulted in %v for player[%d], want %v for player[%d]", g.State(), g.Player(),
+					StateWon, PlayerOne)
+			}
+			var want Cells
+			for _, coords := range tc.cells {
+				want = append(want, mustParseCell(t, coords))
+			}
+			if cells := g.WinningCells(); !slices.Equal(cells, want) {
+				t.Errorf("WinningCells() = %v, want %v", cells, want)
+			}
+		})
+	}
+}
+
+// mustPlay plays the Cell at the given coordinates for the current Player of Game, failing the test if it returns an
+// error
+func mustPlay(t *testing.T, g Game, coords string) {
+	t.Helper()
+	if _, _, err := g.Play(Turn{Cell: mustParseCell(t, coords), Player: g.Player()}); err != nil {
+		t.Fatalf("Play() returned unexpected error: %v", err)
+	}
+}
@@ -8,7 +8,9 @@ const (
 	// MaxSizeHard is the maximum board size supported by the built-in hard bot
 	MaxSizeHard uint8 = math.MaxUint8
 	// MaxSizeImpossible is the maximum board size supported by the built-in impossible bot
-	MaxSizeImpossible uint8 = 3
+	MaxSizeImpossible uint8 = 4
+	// MaxSizeMCTS is the maximum board size supported by the built-in MCTS bot
+	MaxSizeMCTS uint8 = math.MaxUint8
 	// MaxSizeNormal is the maximum board size supported by the built-in normal bot
 	MaxSizeNormal uint8 = math.MaxUint8
 
@@ -18,6 +20,8 @@ const (
 	NameHard = "hard"
 	// NameImpossible is the name of the built-in impossible bot
 	NameImpossible = "impossible"
+	// NameMCTS is the name of the built-in MCTS bot
+	NameMCTS = "mcts"
 	// NameNormal is the name of the built-in normal bot
 	NameNormal = "normal"
 )
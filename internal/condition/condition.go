@@ -0,0 +1,71 @@
+package condition
+
+import (
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"slices"
+)
+
+type (
+	// Condition is satisfied by the winning condition of any Game, whether it's that of tic-tac-toe or of a variant,
+	// for the given types of board, turn, and player
+	Condition[B, T any, P Player] interface {
+		FindWinner(board B) P
+		IsWinningTurn(board B, turn T) bool
+	}
+
+	// Player is satisfied by the player of any Game, which cannot be referenced directly as its package depends on this
+	// one
+	Player interface {
+		~uint8
+		IsValidOrZero() bool
+	}
+
+	// WinningCellsCondition is satisfied by any winning condition that reports the given type of cells that resulted
+	// in a win
+	WinningCellsCondition[B, T, S any] interface {
+		WinningCells(board B, turn T) S
+	}
+)
+
+// FindWinner checks the given board and returns the player that wins based on any of the given conditions or zero if
+// there is no winner.
+//
+// An ErrConditionInvalid is returned if a condition returns an invalid non-zero player.
+func FindWinner[B, T any, P Player, C Condition[B, T, P]](conditions []C, board B) (P, error) {
+	for i, c := range conditions {
+		if player := c.FindWinner(board); !player.IsValidOrZero() {
+			return 0, errs.InvalidCondition(i, fmt.Sprintf("invalid player: %v", player))
+		} else if player > 0 {
+			return player, nil
+		}
+	}
+	return 0, nil
+}
+
+// IsWinningTurn checks the given board and returns whether the turn provided resulted in a win based on any of the
+// given conditions
+func IsWinningTurn[B, T any, P Player, C Condition[B, T, P]](conditions []C, board B, turn T) bool {
+	for _, c := range conditions {
+		if won := c.IsWinningTurn(board, turn); won {
+			return won
+		}
+	}
+	return false
+}
+
+// WinningCells checks the given board and returns the cells that resulted in the turn provided being a winning turn
+// based on any of the given conditions that implement WinningCellsCondition, or nil if there are none
+func WinningCells[B, T any, P Player, E comparable, S ~[]E, C Condition[B, T, P]](conditions []C, board B, turn T) S {
+	var cells S
+	for _, c := range conditions {
+		if wcc, ok := any(c).(WinningCellsCondition[B, T, S]); ok {
+			for _, cell := range wcc.WinningCells(board, turn) {
+				if !slices.Contains(cells, cell) {
+					cells = append(cells, cell)
+				}
+			}
+		}
+	}
+	return cells
+}
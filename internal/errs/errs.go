@@ -0,0 +1,87 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrBot is returned if a Bot fails to take their turn
+	ErrBot = errors.New("bot turn failed")
+	// ErrBotMaxSizeExceeded is returned if a Bot is used in combination with a board whose size exceeds its maximum
+	ErrBotMaxSizeExceeded = errors.New("bot max board size exceeded")
+	// ErrBotNotFound is returned if a Bot with a given name is neither built-in nor registered
+	ErrBotNotFound = errors.New("bot not found")
+	// ErrBotRegistered is returned if attempting to register a Bot using a name that is already in use
+	ErrBotRegistered = errors.New("bot already registered")
+	// ErrConditionInvalid is returned if a Condition returns invalid information
+	ErrConditionInvalid = errors.New("invalid condition")
+	// ErrGameOver is returned if attempting to take a turn or resign while not having StateAwaitingTurn, or to undo or
+	// redo a turn once a Player has resigned
+	ErrGameOver = errors.New("game over")
+	// ErrNotationInvalid is returned if attempting to parse compact notation that is malformed or invalid
+	ErrNotationInvalid = errors.New("invalid notation")
+	// ErrNothingToRedo is returned if attempting to redo a turn when no turn has been undone
+	ErrNothingToRedo = errors.New("nothing to redo")
+	// ErrNothingToUndo is returned if attempting to undo a turn when no turn has been played
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrOptionInvalid is returned if an Option is passed to Start that has been given an invalid argument
+	ErrOptionInvalid = errors.New("invalid option")
+	// ErrOutOfBounds is returned if a given row/column is out-of-bounds
+	ErrOutOfBounds = errors.New("out of bounds")
+	// ErrPlayerNotFound is returned if a given Player cannot be found
+	ErrPlayerNotFound = errors.New("player not found")
+	// ErrTurnInvalid is returned if attempting to take an invalid turn
+	ErrTurnInvalid = errors.New("invalid turn")
+)
+
+// Bot returns an ErrBot for the Bot with the given name that failed with err
+func Bot(name string, err error) error {
+	return fmt.Errorf("%q %w: %w", name, ErrBot, err)
+}
+
+// BotMaxSizeExceeded returns an ErrBotMaxSizeExceeded for the Bot with the given name and maximum board size
+func BotMaxSizeExceeded(name string, maxSize uint8) error {
+	return fmt.Errorf("%q %w: %v", name, ErrBotMaxSizeExceeded, maxSize)
+}
+
+// BotNotFound returns an ErrBotNotFound for the given name
+func BotNotFound(name string) error {
+	return fmt.Errorf("%w: %q", ErrBotNotFound, name)
+}
+
+// BotRegistered returns an ErrBotRegistered for the given name
+func BotRegistered(name string) error {
+	return fmt.Errorf("%w: %q", ErrBotRegistered, name)
+}
+
+// InvalidCondition returns an ErrConditionInvalid for the Condition at the given index with the given reason
+func InvalidCondition(idx int, reason string) error {
+	return fmt.Errorf("%w[%d]: %s", ErrConditionInvalid, idx, reason)
+}
+
+// InvalidNotation returns an ErrNotationInvalid wrapping err
+func InvalidNotation(err error) error {
+	return fmt.Errorf("%w: %w", ErrNotationInvalid, err)
+}
+
+// InvalidOption returns an ErrOptionInvalid for the option with the given name wrapping err
+func InvalidOption(option string, err error) error {
+	return fmt.Errorf("%w[%s]: %w", ErrOptionInvalid, option, err)
+}
+
+// InvalidTurn returns an ErrTurnInvalid with the given reason
+func InvalidTurn(reason string) error {
+	return fmt.Errorf("%w: %s", ErrTurnInvalid, reason)
+}
+
+// OutOfBounds returns an ErrOutOfBounds for the given location (e.g. "row[3]col[0]") that is greater than or equal to
+// size
+func OutOfBounds(location string, size uint8) error {
+	return fmt.Errorf("%w: %s is greater than or equal to %d", ErrOutOfBounds, location, size)
+}
+
+// PlayerNotFound returns an ErrPlayerNotFound for the given player
+func PlayerNotFound[P ~uint8](player P) error {
+	return fmt.Errorf("%w: %d", ErrPlayerNotFound, player)
+}
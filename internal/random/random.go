@@ -0,0 +1,39 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// lockedSource is a rand.Source64 that guards another source of randomness with a lock
+type lockedSource struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r.Seed(seed)
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Uint64()
+}
+
+// NewLocked returns a rand.Rand that draws from the given rand.Rand while holding a lock so that it's safe for
+// concurrent use by multiple goroutines, except for its Read method.
+//
+// The same values are drawn in the same order as they would be from r, so any seed used to create r is still honored.
+// However, r itself must no longer be used directly.
+func NewLocked(r *rand.Rand) *rand.Rand {
+	return rand.New(&lockedSource{r: r})
+}
@@ -0,0 +1,175 @@
+package variant
+
+import (
+	"errors"
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"github.com/neocotic/go-tic-tac-toe/internal/random"
+	"math/rand"
+)
+
+// Bot is satisfied by the Bot of any variant
+type Bot interface {
+	Name() string
+	Player() tictactoe.Player
+}
+
+// Base contains the state shared by the Game of every variant, being the Bot controlling each Player, the current
+// Player, the source of randomness, and the State, along with the methods and option plumbing that go with it.
+//
+// Base is intended to be embedded so that its exported methods are promoted to satisfy the Game of the variant.
+type Base[B Bot] struct {
+	bots   map[tictactoe.Player]B
+	player tictactoe.Player
+	rand   *rand.Rand
+	state  tictactoe.State
+}
+
+// Bot returns the Bot controlling the given Player, or nil if Player is human
+func (b *Base[B]) Bot(player tictactoe.Player) B {
+	return b.bots[player]
+}
+
+// Bots returns each Bot controlling a Player
+func (b *Base[B]) Bots() []B {
+	bots := make([]B, 0, len(b.bots))
+	for _, player := range tictactoe.Players() {
+		if bot, ok := b.bots[player]; ok {
+			bots = append(bots, bot)
+		}
+	}
+	return bots
+}
+
+// IsBotTurn returns whether the current Player is controlled by a Bot
+func (b *Base[B]) IsBotTurn() bool {
+	_, ok := b.bots[b.player]
+	return b.state == tictactoe.StateAwaitingTurn && ok
+}
+
+// Player returns the current Player
+func (b *Base[B]) Player() tictactoe.Player {
+	return b.player
+}
+
+// Rand returns the source of randomness, which is safe for concurrent use, except for its Read method, once Start has
+// been called
+func (b *Base[B]) Rand() *rand.Rand {
+	return b.rand
+}
+
+// Resign ends the game early with the given Player forfeiting and returns the resulting State and Player.
+//
+// An error is returned in following cases:
+//   - ErrGameOver if Base doesn't have StateAwaitingTurn
+//   - ErrPlayerNotFound if Player is invalid
+func (b *Base[B]) Resign(player tictactoe.Player) (tictactoe.State, tictactoe.Player, error) {
+	if b.state != tictactoe.StateAwaitingTurn {
+		return b.state, b.player, errs.ErrGameOver
+	}
+	if !player.IsValid() {
+		return b.state, b.player, errs.PlayerNotFound(player)
+	}
+	b.player = player
+	b.state = tictactoe.StateForfeited
+	return b.state, b.player, nil
+}
+
+// SetBot controls the Player of the given Bot by it, unless that Player is already controlled by a Bot.
+//
+// An ErrOptionInvalid for option is returned if bot has an invalid Player.
+func (b *Base[B]) SetBot(bot B, option string) error {
+	player := bot.Player()
+	if !player.IsValid() {
+		return errs.InvalidOption(option, errs.PlayerNotFound(player))
+	}
+	if _, ok := b.bots[player]; ok {
+		return nil
+	}
+	if b.bots == nil {
+		b.bots = make(map[tictactoe.Player]B, 2)
+	}
+	b.bots[player] = bot
+	return nil
+}
+
+// SetRand sets the source of randomness to the one given.
+//
+// An ErrOptionInvalid for option is returned if r is nil.
+func (b *Base[B]) SetRand(r *rand.Rand, option string) error {
+	if r == nil {
+		return errs.InvalidOption(option, errors.New("rand is nil"))
+	}
+	b.rand = r
+	return nil
+}
+
+// SetSeed sets the source of randomness to one with the given seed
+func (b *Base[B]) SetSeed(seed int64) {
+	b.rand = rand.New(rand.NewSource(seed))
+}
+
+// SetStarter sets the current Player to the one given, unless it has already been set.
+//
+// An ErrOptionInvalid for option is returned if player is invalid.
+func (b *Base[B]) SetStarter(player tictactoe.Player, option string) error {
+	if b.player > 0 {
+		return nil
+	}
+	if !player.IsValid() {
+		return errs.InvalidOption(option, errs.PlayerNotFound(player))
+	}
+	b.player = player
+	return nil
+}
+
+// SetState sets the State and current Player to those given after a turn has been played
+func (b *Base[B]) SetState(state tictactoe.State, player tictactoe.Player) {
+	b.player = player
+	b.state = state
+}
+
+// Start completes Base once every option has been applied, defaulting the current Player to PlayerOne and guarding the
+// source of randomness so that it can be used by a Bot while the game is otherwise in use
+func (b *Base[B]) Start() {
+	if b.player == 0 {
+		b.player = tictactoe.PlayerOne
+	}
+	b.rand = random.NewLocked(b.rand)
+}
+
+// State returns the current State
+func (b *Base[B]) State() tictactoe.State {
+	return b.state
+}
+
+// ValidateTurn returns an error if the given Player cannot currently take a turn.
+//
+// An error is returned in following cases:
+//   - ErrGameOver if Base doesn't have StateAwaitingTurn
+//   - ErrPlayerNotFound if Player is invalid
+//   - ErrTurnInvalid if it's not the turn of Player or Player is controlled by a Bot and allowBotTurn is false
+func (b *Base[B]) ValidateTurn(player tictactoe.Player, allowBotTurn bool) error {
+	if b.state != tictactoe.StateAwaitingTurn {
+		return errs.ErrGameOver
+	}
+	if !player.IsValid() {
+		return errs.PlayerNotFound(player)
+	}
+	if _, ok := b.bots[player]; ok && !allowBotTurn {
+		return errs.InvalidTurn(fmt.Sprintf("human cannot play turn for bot player[%d]", player))
+	}
+	if player != b.player {
+		return errs.InvalidTurn(fmt.Sprintf("player[%d] cannot play turn for player[%d]", player, b.player))
+	}
+	return nil
+}
+
+// NewBase returns a new Base awaiting a turn with a randomly seeded source of randomness
+func NewBase[B Bot]() Base[B] {
+	return Base[B]{
+		rand:  rand.New(rand.NewSource(rand.Int63())),
+		state: tictactoe.StateAwaitingTurn,
+	}
+}
@@ -0,0 +1,251 @@
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	notationEmpty     = '.'
+	notationGameOver  = "-"
+	notationPlayerOne = 'x'
+	notationPlayerTwo = 'o'
+	notationRowSep    = "/"
+)
+
+// position represents a position parsed from compact notation
+type position struct {
+	board     Board
+	player    Player
+	winLength uint8
+}
+
+// Format returns the compact notation for the current position of the given Game.
+//
+// Compact notation is a FEN-like representation of a position, consisting of the following fields separated by a space:
+//   - Board, where each row is separated by "/" and each cell is either "x" (PlayerOne), "o" (PlayerTwo), or "." (no
+//     Player). When parsing, any number of consecutive empty cells can also be represented by their count (e.g. "3"
+//     instead of "...").
+//   - Player whose turn it is, being "x", "o", or "-" if the Board is over (i.e. full or has a winner). Where a Player
+//     has resigned, the Player who would have taken the next turn is used instead so that the position can be parsed.
+//   - Win length, which is optional and only included where it differs from the default (see WithWinLength)
+//
+// For example; "xo./.x./..o x" represents a 3x3 Board where it's the turn of PlayerOne.
+func Format(game Game) string {
+	var player Player
+	switch game.State() {
+	case StateAwaitingTurn:
+		player = game.Player()
+	case StateForfeited:
+		if turn, ok := game.LastTurn(); ok {
+			player = turn.Player.Next()
+		} else {
+			player = game.Snapshot().Starter
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(formatPosition(game.Board(), player))
+	if winLength := game.WinLength(); winLength != min(game.Rows(), game.Columns()) {
+		sb.WriteRune(' ')
+		sb.WriteString(strconv.Itoa(int(winLength)))
+	}
+	return sb.String()
+}
+
+// FormatCell returns the algebraic coordinates of the given Cell, consisting of the column as letters followed by the
+// row as a number, where the top-left Cell is "a1".
+//
+// Columns after "z" continue with "aa", "ab", and so on.
+func FormatCell(cell Cell) string {
+	var col []byte
+	for n := int(cell.Column) + 1; n > 0; n = (n - 1) / 26 {
+		col = append([]byte{byte('a' + (n-1)%26)}, col...)
+	}
+	return string(col) + strconv.Itoa(int(cell.Row)+1)
+}
+
+// FormatBoard returns the compact notation for the given Board (e.g. "xo./.x./..o").
+//
+// Unlike Board.String, the notation can be parsed back into a Board using ParseBoard.
+func FormatBoard(board Board) string {
+	var sb strings.Builder
+	for row, cols := range board {
+		if row > 0 {
+			sb.WriteString(notationRowSep)
+		}
+		for _, player := range cols {
+			sb.WriteRune(formatNotationPlayer(player))
+		}
+	}
+	return sb.String()
+}
+
+// ParseBoard returns the Board represented by the given compact notation (e.g. "xo./.x./..o"), where uppercase
+// players are also accepted (see Format).
+//
+// An ErrNotationInvalid is returned if notation is malformed or represents an invalid Board (see WithBoard).
+func ParseBoard(notation string) (Board, error) {
+	board, err := parseNotationBoard(notation)
+	if err != nil {
+		return nil, fmtInvalidNotationErr(err)
+	}
+	return board, nil
+}
+
+// ParseCell returns the Cell represented by the given algebraic coordinates (e.g. "b2"; see FormatCell), where
+// uppercase letters are also accepted.
+//
+// Cell is not checked against the bounds of any Board.
+//
+// An ErrNotationInvalid is returned if coordinates are malformed or either the column or row exceeds MaxSize.
+func ParseCell(coords string) (Cell, error) {
+	s := strings.ToLower(strings.TrimSpace(coords))
+	i := 0
+	col := 0
+	for ; i < len(s) && s[i] >= 'a' && s[i] <= 'z'; i++ {
+		if col = col*26 + int(s[i]-'a') + 1; col > int(MaxSize) {
+			return Cell{}, fmtInvalidNotationErr(fmt.Errorf("column out of range: %q", coords))
+		}
+	}
+	if i == 0 || i == len(s) {
+		return Cell{}, fmtInvalidNotationErr(fmt.Errorf("must contain column letters followed by row number: %q", coords))
+	}
+	row, err := strconv.ParseUint(s[i:], 10, 16)
+	if err != nil {
+		return Cell{}, fmtInvalidNotationErr(fmt.Errorf("must contain column letters followed by row number: %q", coords))
+	}
+	if row < 1 || row > uint64(MaxSize) {
+		return Cell{}, fmtInvalidNotationErr(fmt.Errorf("row out of range: %q", coords))
+	}
+	return Cell{
+		Column: uint8(col - 1),
+		Row:    uint8(row - 1),
+	}, nil
+}
+
+// ParseGame returns a new Game started from the position represented by the given compact notation (e.g.
+// "xo./.x./..o x"; see Format), optionally customized by providing options.
+//
+// Any options are applied before WithPosition so that, for example, a Bot can be passed.
+//
+// An ErrNotationInvalid is returned if notation is malformed or represents an invalid position. Otherwise, the same
+// errors as Start may be returned.
+func ParseGame(notation string, opts ...Option) (Game, error) {
+	if _, err := parsePosition(notation); err != nil {
+		return nil, fmtInvalidNotationErr(err)
+	}
+	return Start(append(append([]Option{}, opts...), WithPosition(notation))...)
+}
+
+func formatNotationPlayer(player Player) rune {
+	switch player {
+	case PlayerOne:
+		return notationPlayerOne
+	case PlayerTwo:
+		return notationPlayerTwo
+	default:
+		return notationEmpty
+	}
+}
+
+func formatPosition(board Board, player Player) string {
+	var sb strings.Builder
+	sb.WriteString(FormatBoard(board))
+	sb.WriteRune(' ')
+	if player > 0 {
+		sb.WriteRune(formatNotationPlayer(player))
+	} else {
+		sb.WriteString(notationGameOver)
+	}
+	return sb.String()
+}
+
+func parseNotationBoard(notation string) (Board, error) {
+	var board Board
+	for row, s := range strings.Split(strings.ToLower(notation), notationRowSep) {
+		var cols []Player
+		for i := 0; i < len(s); i++ {
+			switch c := s[i]; {
+			case c == notationEmpty:
+				cols = append(cols, 0)
+			case c == notationPlayerOne:
+				cols = append(cols, PlayerOne)
+			case c == notationPlayerTwo:
+				cols = append(cols, PlayerTwo)
+			case c >= '1' && c <= '9':
+				j := i + 1
+				for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+					j++
+				}
+				count, err := strconv.Atoi(s[i:j])
+				if err != nil || count > int(MaxSize) {
+					return nil, fmt.Errorf("row[%d] contains invalid number of empty cells: %q", row, s[i:j])
+				}
+				cols = append(cols, make([]Player, count)...)
+				i = j - 1
+			default:
+				return nil, fmt.Errorf("row[%d] contains unexpected character: %q", row, c)
+			}
+		}
+		board = append(board, cols)
+	}
+	if _, _, _, _, err := board.check(); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func parsePosition(notation string) (position, error) {
+	var p position
+	fields := strings.Fields(notation)
+	if len(fields) < 2 || len(fields) > 3 {
+		return p, errors.New("must contain board, player, and optionally win length")
+	}
+
+	var err error
+	if p.board, err = parseNotationBoard(fields[0]); err != nil {
+		return p, err
+	}
+
+	switch strings.ToLower(fields[1]) {
+	case string(notationPlayerOne):
+		p.player = PlayerOne
+	case string(notationPlayerTwo):
+		p.player = PlayerTwo
+	case notationGameOver:
+	default:
+		return p, fmt.Errorf("unexpected player: %q", fields[1])
+	}
+	if starter, _, _, _, _ := p.board.check(); starter > 0 && p.player > 0 && p.player != starter {
+		return p, fmt.Errorf("board requires player[%d] to take turn", starter)
+	}
+
+	if len(fields) == 3 {
+		winLength, err := strconv.ParseUint(fields[2], 10, 8)
+		if err != nil || winLength < uint64(MinWinLength) || winLength > uint64(max(len(p.board), len(p.board[0]))) {
+			return p, fmt.Errorf("win length out of range: %s", fields[2])
+		}
+		p.winLength = uint8(winLength)
+	}
+
+	if p.player == 0 && !p.isOver() {
+		return p, fmt.Errorf("player cannot be %q while board is not over", notationGameOver)
+	}
+	return p, nil
+}
+
+// isOver returns whether the board of position is either full or has a winner based on the standard winning
+// Conditions for its win length
+func (p position) isOver() bool {
+	if len(p.board.FindEmpty()) == 0 {
+		return true
+	}
+	winLength := p.winLength
+	if winLength == 0 {
+		winLength = uint8(min(len(p.board), len(p.board[0])))
+	}
+	winner, _ := newStandardConditions(winLength).FindWinner(p.board)
+	return winner > 0
+}
@@ -0,0 +1,179 @@
+package tictactoe
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	for _, notation := range []string{
+		"x../.../... o",
+		"xo./.x./..o x",
+		"xo./.x./..o o",
+		"xxx/oo./... -",
+		"xox/xoo/oxx -",
+		"..../..../..../.... x 3",
+		"...../...../x.... o",
+	} {
+		t.Run(notation, func(t *testing.T) {
+			g, err := ParseGame(notation)
+			if err != nil {
+				t.Fatalf("ParseGame() returned unexpected error: %v", err)
+			}
+			if got := Format(g); got != notation {
+				t.Errorf("Format() = %q, want %q", got, notation)
+			}
+		})
+	}
+}
+
+func TestFormat_Forfeited(t *testing.T) {
+	g := MustStart()
+	if _, _, err := g.Play(Turn{Player: PlayerOne}); err != nil {
+		t.Fatalf("Play() returned unexpected error: %v", err)
+	}
+	if _, _, err := g.Resign(PlayerTwo); err != nil {
+		t.Fatalf("Resign() returned unexpected error: %v", err)
+	}
+	const want = "x../.../... o"
+	if got := Format(g); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+	if _, err := ParseGame(Format(g)); err != nil {
+		t.Errorf("ParseGame() returned unexpected error: %v", err)
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	for _, tc := range []struct {
+		cell   Cell
+		coords string
+	}{
+		{Cell{Column: 0, Row: 0}, "a1"},
+		{Cell{Column: 1, Row: 1}, "b2"},
+		{Cell{Column: 25, Row: 9}, "z10"},
+		{Cell{Column: 26, Row: 0}, "aa1"},
+		{Cell{Column: 51, Row: 99}, "az100"},
+		{Cell{Column: 52, Row: 0}, "ba1"},
+		{Cell{Column: MaxSize - 1, Row: MaxSize - 1}, "iu255"},
+	} {
+		t.Run(tc.coords, func(t *testing.T) {
+			if got := FormatCell(tc.cell); got != tc.coords {
+				t.Errorf("FormatCell() = %q, want %q", got, tc.coords)
+			}
+			got, err := ParseCell(tc.coords)
+			if err != nil {
+				t.Fatalf("ParseCell() returned unexpected error: %v", err)
+			}
+			if got != tc.cell {
+				t.Errorf("ParseCell() = %v, want %v", got, tc.cell)
+			}
+		})
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	for _, tc := range []struct {
+		notation string
+		want     string
+	}{
+		{"xo./.x./..o", "xo./.x./..o"},
+		{"XO./.X./..O", "xo./.x./..o"},
+		{"x2/3/3", "x../.../..."},
+		{"12/12/12", "............/............/............"},
+		{"xo1/1x1/2o", "xo./.x./..o"},
+	} {
+		t.Run(tc.notation, func(t *testing.T) {
+			board, err := ParseBoard(tc.notation)
+			if err != nil {
+				t.Fatalf("ParseBoard() returned unexpected error: %v", err)
+			}
+			if got := FormatBoard(board); got != tc.want {
+				t.Errorf("FormatBoard() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseBoard_Invalid(t *testing.T) {
+	for _, notation := range []string{
+		"",
+		"xo/../..",
+		"xo./.x.",
+		"xo./.x./..",
+		"xq./.../...",
+		"x0./.../...",
+		"xx./.../...",
+		"oo./.../...",
+		"x256/.../...",
+	} {
+		t.Run(notation, func(t *testing.T) {
+			if _, err := ParseBoard(notation); !errors.Is(err, ErrNotationInvalid) {
+				t.Errorf("ParseBoard() returned error %v, want %v", err, ErrNotationInvalid)
+			}
+		})
+	}
+}
+
+func TestParseCell(t *testing.T) {
+	for _, tc := range []struct {
+		coords string
+		want   Cell
+	}{
+		{"B2", Cell{Column: 1, Row: 1}},
+		{" c3 ", Cell{Column: 2, Row: 2}},
+		{"Aa1", Cell{Column: 26, Row: 0}},
+	} {
+		t.Run(tc.coords, func(t *testing.T) {
+			got, err := ParseCell(tc.coords)
+			if err != nil {
+				t.Fatalf("ParseCell() returned unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseCell() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCell_Invalid(t *testing.T) {
+	for _, coords := range []string{
+		"",
+		"a",
+		"1",
+		"1a",
+		"a0",
+		"a-1",
+		"a1b",
+		"a256",
+		"iv1",
+		"zzz1",
+	} {
+		t.Run(coords, func(t *testing.T) {
+			if _, err := ParseCell(coords); !errors.Is(err, ErrNotationInvalid) {
+				t.Errorf("ParseCell() returned error %v, want %v", err, ErrNotationInvalid)
+			}
+		})
+	}
+}
+
+func TestParseGame_Invalid(t *testing.T) {
+	for _, notation := range []string{
+		"",
+		"xo./.x./..o",
+		"xo./.x./..o x 3 3",
+		"xo./.x./..o z",
+		"xq./.../... o",
+		"x../.../... x",
+		"x../.../... -",
+		"x../.../... o 2",
+		"x../.../... o 4",
+		"x../.../... o a",
+	} {
+		t.Run(notation, func(t *testing.T) {
+			if _, err := ParseGame(notation); !errors.Is(err, ErrNotationInvalid) {
+				t.Errorf("ParseGame() returned error %v, want %v", err, ErrNotationInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package tictactoe
+
+type (
+	// Observer is notified of events that occur within a Game, allowing side effects (e.g. logging, statistics) to be
+	// plugged into a Game without wrapping every call to it.
+	//
+	// Each method is called synchronously by Game once it has been fully updated, so an Observer should return quickly
+	// and must never call any method that modifies Game (e.g. Play) or risk corrupting it. No events are emitted while a
+	// Game is being started or restored.
+	//
+	// Where Game was started using WithConcurrencySafety, each method is called while the lock of Game is held. An
+	// Observer must therefore only read from the Game passed to it, which is not guarded by the lock, and never call
+	// the Game returned by Start, including from any goroutine that it waits for, as doing so will deadlock. Any slow
+	// work (e.g. writing to disk) should be handed off to another goroutine without waiting for it to finish.
+	//
+	// NopObserver can be embedded to implement only the methods of interest.
+	Observer interface {
+		// BotTurnFinished is called once the given Bot has finished checking the Board for its turn, before that turn
+		// is played, with either the Cell representing that turn's location on Board or the error that prevented it
+		BotTurnFinished(game Game, bot Bot, cell Cell, err error)
+		// BotTurnStarted is called once the given Bot has been requested to take its turn
+		BotTurnStarted(game Game, bot Bot)
+		// GameOver is called once Game no longer has StateAwaitingTurn, following StateChanged, with the resulting State
+		// and Player
+		GameOver(game Game, state State, player Player)
+		// StateChanged is called whenever the State of Game changes (e.g. to StateWon, or back to StateAwaitingTurn
+		// following Undo)
+		StateChanged(game Game, from, to State)
+		// TurnPlayed is called whenever a Turn is played, including those taken by a Bot or replayed by Redo
+		TurnPlayed(game Game, turn Turn)
+	}
+
+	// NopObserver is an Observer that does nothing and is intended to be embedded within partial implementations
+	NopObserver struct{}
+)
+
+// BotTurnFinished does nothing
+func (NopObserver) BotTurnFinished(Game, Bot, Cell, error) {}
+
+// BotTurnStarted does nothing
+func (NopObserver) BotTurnStarted(Game, Bot) {}
+
+// GameOver does nothing
+func (NopObserver) GameOver(Game, State, Player) {}
+
+// StateChanged does nothing
+func (NopObserver) StateChanged(Game, State, State) {}
+
+// TurnPlayed does nothing
+func (NopObserver) TurnPlayed(Game, Turn) {}
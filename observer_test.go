@@ -0,0 +1,88 @@
+package tictactoe
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recordingObserver is an Observer that records each event along with the State of the Game passed to it, which is
+// read while the lock of Game is held where it was started using WithConcurrencySafety
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) BotTurnFinished(game Game, bot Bot, cell Cell, err error) {
+	o.record(game, "BotTurnFinished(%s, %s, %v)", bot.Name(), FormatCell(cell), err)
+}
+
+func (o *recordingObserver) BotTurnStarted(game Game, bot Bot) {
+	o.record(game, "BotTurnStarted(%s)", bot.Name())
+}
+
+func (o *recordingObserver) GameOver(game Game, state State, player Player) {
+	o.record(game, "GameOver(%v, %v)", state, player)
+}
+
+func (o *recordingObserver) StateChanged(game Game, from, to State) {
+	o.record(game, "StateChanged(%v, %v)", from, to)
+}
+
+func (o *recordingObserver) TurnPlayed(game Game, turn Turn) {
+	o.record(game, "TurnPlayed(%s, %v)", FormatCell(turn.Cell), turn.Player)
+}
+
+func (o *recordingObserver) record(game Game, format string, args ...any) {
+	_ = game.Board()
+	_ = game.WinningCells()
+	o.events = append(o.events, fmt.Sprintf(format, args...)+fmt.Sprintf(" %d %v", len(game.Turns()), game.State()))
+}
+
+func TestObserver(t *testing.T) {
+	obs := &recordingObserver{}
+	g := MustStart(WithPosition("x../.o./o.x x"), WithImpossibleBot(PlayerTwo), WithObserver(obs),
+		WithConcurrencySafety(), WithSeed(1))
+
+	// Blocking the opponent creates two threats, only one of which can be blocked by the bot
+	assertEvents(t, obs, func() error {
+		_, _, err := g.Play(Turn{Cell: mustParseCell(t, "c1"), Player: PlayerOne})
+		return err
+	}, "TurnPlayed(c1, X) 5 Awaiting Turn")
+
+	assertEvents(t, obs, func() error {
+		_, _, err := g.AllowBotTurn()
+		return err
+	}, "BotTurnStarted(impossible) 5 Awaiting Turn",
+		"BotTurnFinished(impossible, b1, <nil>) 5 Awaiting Turn",
+		"TurnPlayed(b1, O) 6 Awaiting Turn")
+
+	assertEvents(t, obs, func() error {
+		_, _, err := g.Play(Turn{Cell: mustParseCell(t, "c2"), Player: PlayerOne})
+		return err
+	}, "TurnPlayed(c2, X) 7 Won",
+		"StateChanged(Awaiting Turn, Won) 7 Won",
+		"GameOver(Won, X) 7 Won")
+}
+
+// assertEvents calls the given function, failing the test if it returns an error, doesn't return in time (e.g. due to a
+// deadlock while notifying the given recordingObserver), or if the events recorded by it are not those wanted
+func assertEvents(t *testing.T, obs *recordingObserver, fn func() error, want ...string) {
+	t.Helper()
+	obs.events = nil
+	done := make(chan error)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("returned unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadlocked while notifying observer")
+	}
+	if !slices.Equal(obs.events, want) {
+		t.Errorf("observer recorded events %q, want %q", obs.events, want)
+	}
+}
@@ -0,0 +1,250 @@
+package qubic
+
+import (
+	"context"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"slices"
+)
+
+// Bot represents a machine-controlled player of a Game
+type Bot interface {
+	// Name returns the name of the Bot
+	Name() string
+	// Player returns the Player for which the Bot is playing
+	Player() tictactoe.Player
+	// Turn allows the Bot to check Game for the best possible Cell, which must be one of Game.LegalCells.
+	//
+	// Turn is only ever called if there is at least one legal Cell, however, it must stop checking Game and return the
+	// error of the given context as soon as possible once context is done.
+	Turn(ctx context.Context, game Game) (Cell, error)
+}
+
+const (
+	nameHeuristic = "heuristic"
+	nameRandom    = "random"
+)
+
+const (
+	// heuristicCandidates is the maximum number of the highest scoring cells checked for whether they stop a forced
+	// win by the opponent, which limits the cost of searching a board with so many empty cells
+	heuristicCandidates = 12
+	// heuristicDepth is the maximum number of threats in a sequence searched for a forced win
+	heuristicDepth = 6
+)
+
+var (
+	// heuristicAttack contains the value of a line through a cell for each number of cells already occupied within it
+	// by the Bot, where none are occupied by the opponent
+	heuristicAttack = [Size]int{1, 4, 16, 0}
+	// heuristicDefend contains the value of a line through a cell for each number of cells already occupied within it
+	// by the opponent, where none are occupied by the Bot
+	heuristicDefend = [Size]int{0, 3, 12, 0}
+)
+
+type heuristicBot struct {
+	player tictactoe.Player
+}
+
+func (b *heuristicBot) Name() string {
+	return nameHeuristic
+}
+
+func (b *heuristicBot) Player() tictactoe.Player {
+	return b.player
+}
+
+func (b *heuristicBot) Turn(ctx context.Context, game Game) (Cell, error) {
+	board, opponent := game.Board(), b.player.Next()
+	if wins := findWins(board, b.player); len(wins) > 0 {
+		return wins[0], nil
+	}
+	if blocks := findWins(board, opponent); len(blocks) > 0 {
+		return blocks[game.Rand().Intn(len(blocks))], nil
+	}
+	if cell, ok := findForcedWin(ctx, board, b.player, heuristicDepth); ok {
+		return cell, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return Cell{}, err
+	}
+
+	candidates := b.rank(board, game.Rand().Perm(int(Size)*int(Size)*int(Size)))
+	threat, threatened := findForcedWin(ctx, board, opponent, heuristicDepth)
+	if !threatened {
+		return candidates[0], nil
+	}
+	// Opponent can force a win so check whether any of the highest scoring cells, or the first cell of their threat,
+	// stops them
+	candidates = candidates[:min(len(candidates), heuristicCandidates)]
+	if !slices.Contains(candidates, threat) {
+		candidates = append(candidates, threat)
+	}
+	for _, cell := range candidates {
+		next := board
+		next[cell.Layer][cell.Row][cell.Column] = b.player
+		if _, ok := findForcedWin(ctx, next, opponent, heuristicDepth); !ok {
+			if err := ctx.Err(); err != nil {
+				return Cell{}, err
+			}
+			return cell, nil
+		}
+	}
+	return threat, nil
+}
+
+// rank returns every empty cell on board ordered by how valuable it is to the Bot, from most to least, based on the
+// lines passing through it, where order contains a permutation of each index used to break ties
+func (b *heuristicBot) rank(board Board, order []int) Cells {
+	opponent := b.player.Next()
+	scores := make(map[Cell]int)
+	for i := range order {
+		cell := cellAt(i)
+		if board.PlayerAt(cell) > 0 {
+			continue
+		}
+		var score int
+		for _, l := range linesThrough[i] {
+			own, opp := countLine(board, lines[l], b.player), countLine(board, lines[l], opponent)
+			switch {
+			case opp == 0:
+				score += heuristicAttack[own]
+			case own == 0:
+				score += heuristicDefend[opp]
+			}
+		}
+		scores[cell] = score
+	}
+	cells := make(Cells, 0, len(scores))
+	for cell := range scores {
+		cells = append(cells, cell)
+	}
+	slices.SortFunc(cells, func(a, b Cell) int {
+		if scores[a] != scores[b] {
+			return scores[b] - scores[a]
+		}
+		return order[index(a)] - order[index(b)]
+	})
+	return cells
+}
+
+// NewHeuristicBot returns a new Bot that searches sequences of threats, where each forces the opponent to block, for a
+// forced win and otherwise values each cell based on the lines that it extends or blocks
+func NewHeuristicBot(player tictactoe.Player) Bot {
+	return &heuristicBot{player}
+}
+
+type randomBot struct {
+	player tictactoe.Player
+}
+
+func (b *randomBot) Name() string {
+	return nameRandom
+}
+
+func (b *randomBot) Player() tictactoe.Player {
+	return b.player
+}
+
+func (b *randomBot) Turn(_ context.Context, game Game) (Cell, error) {
+	cells := game.LegalCells()
+	return cells[game.Rand().Intn(len(cells))], nil
+}
+
+// NewRandomBot returns a new Bot that takes a random legal Cell
+func NewRandomBot(player tictactoe.Player) Bot {
+	return &randomBot{player}
+}
+
+// BotNames returns the names of all built-in bots in alphabetical order
+func BotNames() []string {
+	return []string{nameHeuristic, nameRandom}
+}
+
+// NewBot returns a new built-in Bot with the given name playing for player.
+//
+// An ErrBotNotFound is returned if no Bot is built-in with name.
+func NewBot(name string, player tictactoe.Player) (Bot, error) {
+	switch name {
+	case nameHeuristic:
+		return NewHeuristicBot(player), nil
+	case nameRandom:
+		return NewRandomBot(player), nil
+	default:
+		return nil, errs.BotNotFound(name)
+	}
+}
+
+// countLine returns the number of cells within the given line occupied by player on board
+func countLine(board Board, line [Size]Cell, player tictactoe.Player) (count int) {
+	for _, cell := range line {
+		if board.PlayerAt(cell) == player {
+			count++
+		}
+	}
+	return
+}
+
+// findForcedWin returns the first cell of a sequence of threats, each forcing the opponent to block it without creating
+// a threat of their own, through which player can take two threats at once and so win, where possible. The sequence is
+// limited to depth threats.
+//
+// Neither player can have a line that is only missing one cell on board.
+func findForcedWin(ctx context.Context, board Board, player tictactoe.Player, depth int) (Cell, bool) {
+	if depth == 0 || ctx.Err() != nil {
+		return Cell{}, false
+	}
+	opponent := player.Next()
+	for _, cell := range board.FindEmpty() {
+		threats := findThreats(board, cell, player)
+		if len(threats) == 0 {
+			continue
+		}
+		next := board
+		next[cell.Layer][cell.Row][cell.Column] = player
+		if len(threats) > 1 {
+			return cell, true
+		}
+		next[threats[0].Layer][threats[0].Row][threats[0].Column] = opponent
+		if len(findWins(next, opponent)) > 0 {
+			continue
+		}
+		if _, ok := findForcedWin(ctx, next, player, depth-1); ok {
+			return cell, true
+		}
+	}
+	return Cell{}, false
+}
+
+// findThreats returns the cells on board that would each win for player if they were to take the given cell, which must
+// be empty
+func findThreats(board Board, cell Cell, player tictactoe.Player) Cells {
+	var cells Cells
+	for _, l := range linesThrough[index(cell)] {
+		if countLine(board, lines[l], player) != int(Size)-2 || countLine(board, lines[l], player.Next()) > 0 {
+			continue
+		}
+		for _, c := range lines[l] {
+			if c != cell && board.PlayerAt(c) == 0 && !slices.Contains(cells, c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// findWins returns the cells on board that would win for player if they were to take any of them
+func findWins(board Board, player tictactoe.Player) Cells {
+	var cells Cells
+	for _, line := range lines {
+		if countLine(board, line, player) != int(Size)-1 {
+			continue
+		}
+		for _, cell := range line {
+			if board.PlayerAt(cell) == 0 && !slices.Contains(cells, cell) {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
@@ -0,0 +1,599 @@
+package qubic
+
+import (
+	"context"
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/internal/condition"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"github.com/neocotic/go-tic-tac-toe/internal/variant"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Size is the number of layers, rows, and columns of a Board, as well as the number of cells in each line
+const Size uint8 = 4
+
+// Board contains all player turns, indexed by layer, row, and then column
+type Board [Size][Size][Size]tictactoe.Player
+
+// FindEmpty returns all Cells within Board that do not contain a Player
+func (b Board) FindEmpty() Cells {
+	var cells Cells
+	for layer, rows := range b {
+		for row, cols := range rows {
+			for col, player := range cols {
+				if player == 0 {
+					cells = append(cells, Cell{
+						Column: uint8(col),
+						Layer:  uint8(layer),
+						Row:    uint8(row),
+					})
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// PlayerAt returns the Player occupying the given Cell within Board, if any, where Cell must not be out-of-bounds
+func (b Board) PlayerAt(cell Cell) tictactoe.Player {
+	return b[cell.Layer][cell.Row][cell.Column]
+}
+
+// String returns an ASCII representation of Board, where each layer is placed side by side starting with the first
+func (b Board) String() string {
+	var sb strings.Builder
+	for row := 0; row < int(Size); row++ {
+		for layer := 0; layer < int(Size); layer++ {
+			if layer > 0 {
+				sb.WriteString(" |")
+			}
+			for col := 0; col < int(Size); col++ {
+				sb.WriteRune(' ')
+				if player := b[layer][row][col]; player > 0 {
+					sb.WriteString(player.String())
+				} else {
+					sb.WriteRune('.')
+				}
+			}
+		}
+		if row < int(Size)-1 {
+			sb.WriteRune('\n')
+		}
+	}
+	return sb.String()
+}
+
+type (
+	// Cell represents the location of a cell on a Board
+	Cell struct {
+		// Column is the column of Cell within its layer
+		Column uint8 `json:"column"`
+		// Layer is the layer of Cell
+		Layer uint8 `json:"layer"`
+		// Row is the row of Cell within its layer
+		Row uint8 `json:"row"`
+	}
+
+	// Cells contains multiple Board cells
+	Cells []Cell
+)
+
+// String returns the number of the layer followed by the algebraic coordinates of the cell within it, separated by a
+// slash (e.g. "2/a1")
+func (c Cell) String() string {
+	return fmt.Sprintf("%d/%s", c.Layer+1, tictactoe.FormatCell(tictactoe.Cell{Column: c.Column, Row: c.Row}))
+}
+
+type (
+	// Condition represents a check for a specific winning condition
+	Condition interface {
+		// FindWinner checks the given Board and returns the Player that wins based on the Condition or zero if there is
+		// no winner
+		FindWinner(board Board) tictactoe.Player
+		// IsWinningTurn checks the given Board and returns whether Turn provided resulted in a win based on the
+		// Condition
+		IsWinningTurn(board Board, turn Turn) bool
+	}
+
+	// Conditions contains multiple winning conditions
+	Conditions []Condition
+
+	// WinningCellsCondition is an optional interface that may be implemented by a Condition to report the Cells that
+	// resulted in a win
+	WinningCellsCondition interface {
+		Condition
+		// WinningCells checks the given Board and returns the Cells that resulted in the Turn provided being a winning
+		// turn based on the Condition, or nil if it was not a winning turn
+		WinningCells(board Board, turn Turn) Cells
+	}
+)
+
+// FindWinner checks the given Board and returns the Player that wins based on any of the Conditions or zero if there is
+// no winner.
+//
+// An ErrConditionInvalid is returned if a Condition returns an invalid non-zero Player.
+func (cs Conditions) FindWinner(board Board) (tictactoe.Player, error) {
+	return condition.FindWinner[Board, Turn, tictactoe.Player](cs, board)
+}
+
+// IsWinningTurn checks the given Board and returns whether the Turn provided resulted in a win based on any of the
+// Conditions
+func (cs Conditions) IsWinningTurn(board Board, turn Turn) bool {
+	return condition.IsWinningTurn[Board, Turn, tictactoe.Player](cs, board, turn)
+}
+
+// WinningCells checks the given Board and returns the Cells that resulted in the Turn provided being a winning turn
+// based on any of the Conditions that implement WinningCellsCondition, or nil if there are none
+func (cs Conditions) WinningCells(board Board, turn Turn) Cells {
+	return condition.WinningCells[Board, Turn, tictactoe.Player, Cell, Cells](cs, board, turn)
+}
+
+// StandardConditions returns the winning Conditions used by every Game, where a Player must occupy all cells of any of
+// the 76 lines of Board to win, including rows, columns, and diagonals within a layer as well as those through every
+// layer (e.g. the space diagonals between opposite corners).
+//
+// These can be used to check a Board outside of a Game.
+func StandardConditions() Conditions {
+	return Conditions{&lineCondition{}}
+}
+
+// lines contains every line of Board, each being a run of Size cells in a straight line
+var lines = newLines()
+
+// linesThrough contains the indices of lines passing through each cell, indexed by index
+var linesThrough = newLinesThrough()
+
+type lineCondition struct{}
+
+func (c *lineCondition) FindWinner(board Board) tictactoe.Player {
+	for _, line := range lines {
+		if player := lineOwner(board, line); player > 0 {
+			return player
+		}
+	}
+	return 0
+}
+
+func (c *lineCondition) IsWinningTurn(board Board, turn Turn) bool {
+	if board.PlayerAt(turn.Cell) != turn.Player {
+		return false
+	}
+	for _, i := range linesThrough[index(turn.Cell)] {
+		if lineOwner(board, lines[i]) == turn.Player {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *lineCondition) WinningCells(board Board, turn Turn) Cells {
+	if board.PlayerAt(turn.Cell) != turn.Player {
+		return nil
+	}
+	var cells Cells
+	for _, i := range linesThrough[index(turn.Cell)] {
+		if lineOwner(board, lines[i]) == turn.Player {
+			for _, cell := range lines[i] {
+				if !slices.Contains(cells, cell) {
+					cells = append(cells, cell)
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// lineOwner returns the Player that occupies every cell of the given line on board, or zero if there is no such Player
+func lineOwner(board Board, line [Size]Cell) tictactoe.Player {
+	player := board.PlayerAt(line[0])
+	for _, cell := range line[1:] {
+		if board.PlayerAt(cell) != player {
+			return 0
+		}
+	}
+	return player
+}
+
+// newLines returns every line of Board by heading in each direction from every cell in which a line can start, where
+// only one of each pair of opposite directions is used so that no line is included twice
+func newLines() [][Size]Cell {
+	var result [][Size]Cell
+	for dLayer := 0; dLayer <= 1; dLayer++ {
+		for dRow := -1; dRow <= 1; dRow++ {
+			for dCol := -1; dCol <= 1; dCol++ {
+				// Skip no direction and the opposite of any direction already used
+				if dLayer == 0 && (dRow < 0 || (dRow == 0 && dCol <= 0)) {
+					continue
+				}
+				for i := 0; i < int(Size)*int(Size)*int(Size); i++ {
+					start := cellAt(i)
+					layer, row, col := int(start.Layer), int(start.Row), int(start.Column)
+					// Only lines spanning Board from its first cell are included
+					if isInBounds(layer-dLayer, row-dRow, col-dCol) {
+						continue
+					}
+					last := int(Size) - 1
+					if !isInBounds(layer+last*dLayer, row+last*dRow, col+last*dCol) {
+						continue
+					}
+					var line [Size]Cell
+					for j := range line {
+						line[j] = Cell{
+							Column: uint8(col + j*dCol),
+							Layer:  uint8(layer + j*dLayer),
+							Row:    uint8(row + j*dRow),
+						}
+					}
+					result = append(result, line)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// newLinesThrough returns the indices of lines passing through each cell, indexed by index
+func newLinesThrough() [][]int {
+	result := make([][]int, int(Size)*int(Size)*int(Size))
+	for i, line := range lines {
+		for _, cell := range line {
+			result[index(cell)] = append(result[index(cell)], i)
+		}
+	}
+	return result
+}
+
+// Turn represents a turn that is either to be taken or has already been taken
+type Turn struct {
+	// Cell is the location of the cell
+	Cell
+	// Player is the Player
+	Player tictactoe.Player `json:"player"`
+}
+
+type (
+	// Game represents a single session of three-dimensional tic-tac-toe (a.k.a. Qubic), played on a Board with Size
+	// layers, each having Size rows and columns.
+	//
+	// A Player wins Game by occupying every cell of any line, whether it lies within a single layer or passes through
+	// every layer, with the standard winning Conditions including all 76 such lines.
+	//
+	// Game is not safe for concurrent use by multiple goroutines.
+	Game interface {
+		// AllowBotTurn requests a turn from a Bot, where applicable, and plays that Turn.
+		//
+		// Nothing happens if Game doesn't have StateAwaitingTurn or the current Player is not controlled by a Bot.
+		//
+		// An ErrBot is returned if the Bot fails to take their turn or their turn is invalid due to the same
+		// constraints as applied to Play.
+		AllowBotTurn() (tictactoe.State, tictactoe.Player, error)
+		// AllowBotTurnContext is the same as AllowBotTurn except that the given context is passed to the Bot so that it
+		// can be cancelled or have a deadline imposed, with any turn taken after context is done being discarded.
+		AllowBotTurnContext(ctx context.Context) (tictactoe.State, tictactoe.Player, error)
+		// Board returns a copy of Board
+		Board() Board
+		// Bot returns the Bot controlling the given Player, or nil if Player is human
+		Bot(player tictactoe.Player) Bot
+		// Conditions returns a copy of the winning conditions for Game
+		Conditions() Conditions
+		// IsBotTurn returns whether the current Player is controlled by a Bot.
+		//
+		// If Game does not have StateAwaitingTurn, false will always be returned.
+		IsBotTurn() bool
+		// LastTurn returns the last Turn played, where possible
+		LastTurn() (Turn, bool)
+		// LegalCells returns every Cell in which the current Player can take their turn, being every empty Cell.
+		//
+		// Nil is returned if Game doesn't have StateAwaitingTurn.
+		LegalCells() Cells
+		// Play takes the given Turn and returns the resulting State and Player.
+		//
+		// The resulting Player will vary depending on State:
+		//  - For StateAwaitingTurn it's the Player to take the next turn
+		//  - For StateDraw it's zero
+		//  - For StateWon it's the given Player who's won
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game doesn't have StateAwaitingTurn
+		//  - ErrOutOfBounds if Turn's Cell is out-of-bounds
+		//  - ErrPlayerNotFound if Turn's Player is invalid
+		//  - ErrTurnInvalid if Turn is invalid (e.g. not turn of Player, cell taken)
+		Play(turn Turn) (tictactoe.State, tictactoe.Player, error)
+		// Player returns the current Player, where appropriate.
+		//
+		// The Player will vary depending on Game's State:
+		//  - For StateAwaitingTurn it's the Player to take the next turn
+		//  - For StateDraw it's zero
+		//  - For StateWon it's the winning Player
+		//  - For StateForfeited it's the Player who resigned
+		Player() tictactoe.Player
+		// PlayerAt returns the Player occupying the given Cell, if any.
+		//
+		// An ErrOutOfBounds is returned if cell is out-of-bounds.
+		PlayerAt(cell Cell) (tictactoe.Player, error)
+		// Rand returns the source of randomness used by Game and any built-in Bot.
+		//
+		// It's safe for concurrent use, except for its Read method, so that a Bot can use it while Game is otherwise in
+		// use.
+		Rand() *rand.Rand
+		// Resign ends Game early with the given Player forfeiting and returns the resulting State and Player.
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game doesn't have StateAwaitingTurn
+		//  - ErrPlayerNotFound if Player is invalid
+		Resign(player tictactoe.Player) (tictactoe.State, tictactoe.Player, error)
+		// State returns the current State
+		State() tictactoe.State
+		// String returns an ASCII representation of Board, where each layer is placed side by side
+		String() string
+		// Turns returns a copy of each Turn already played
+		Turns() []Turn
+		// WinningCells returns the Cells that resulted in the win, where possible.
+		//
+		// Nil is returned if Game does not have StateWon.
+		WinningCells() Cells
+	}
+
+	game struct {
+		variant.Base[Bot]
+		board        Board
+		conditions   Conditions
+		turns        []Turn
+		winningCells Cells
+	}
+)
+
+func (g *game) AllowBotTurn() (tictactoe.State, tictactoe.Player, error) {
+	return g.AllowBotTurnContext(context.Background())
+}
+
+func (g *game) AllowBotTurnContext(ctx context.Context) (tictactoe.State, tictactoe.Player, error) {
+	if !g.IsBotTurn() {
+		return g.State(), g.Player(), nil
+	}
+	bot := g.Bot(g.Player())
+	cell, err := bot.Turn(ctx, g)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return g.State(), g.Player(), errs.Bot(bot.Name(), err)
+	}
+	if _, _, err = g.play(Turn{Cell: cell, Player: g.Player()}, true); err != nil {
+		err = errs.Bot(bot.Name(), err)
+	}
+	return g.State(), g.Player(), err
+}
+
+func (g *game) Board() Board {
+	return g.board
+}
+
+func (g *game) Conditions() Conditions {
+	return append(Conditions(nil), g.conditions...)
+}
+
+func (g *game) LastTurn() (Turn, bool) {
+	if l := len(g.turns); l == 0 {
+		return Turn{}, false
+	} else {
+		return g.turns[l-1], true
+	}
+}
+
+func (g *game) LegalCells() Cells {
+	if g.State() != tictactoe.StateAwaitingTurn {
+		return nil
+	}
+	return g.board.FindEmpty()
+}
+
+func (g *game) Play(turn Turn) (tictactoe.State, tictactoe.Player, error) {
+	return g.play(turn, false)
+}
+
+func (g *game) PlayerAt(cell Cell) (tictactoe.Player, error) {
+	if err := validateBounds(cell); err != nil {
+		return 0, err
+	}
+	return g.board.PlayerAt(cell), nil
+}
+
+func (g *game) String() string {
+	return g.board.String()
+}
+
+func (g *game) Turns() []Turn {
+	return append([]Turn(nil), g.turns...)
+}
+
+func (g *game) WinningCells() Cells {
+	return append(Cells(nil), g.winningCells...)
+}
+
+func (g *game) play(turn Turn, allowBotTurn bool) (tictactoe.State, tictactoe.Player, error) {
+	if err := g.validateTurn(turn, allowBotTurn); err != nil {
+		return g.State(), g.Player(), err
+	}
+
+	g.board[turn.Layer][turn.Row][turn.Column] = turn.Player
+	g.turns = append(g.turns, turn)
+	if g.conditions.IsWinningTurn(g.board, turn) {
+		g.SetState(tictactoe.StateWon, turn.Player)
+		g.winningCells = g.conditions.WinningCells(g.board, turn)
+	} else if len(g.turns) >= int(Size)*int(Size)*int(Size) {
+		g.SetState(tictactoe.StateDraw, 0)
+	} else {
+		g.SetState(tictactoe.StateAwaitingTurn, turn.Player.Next())
+	}
+	return g.State(), g.Player(), nil
+}
+
+func (g *game) validateTurn(turn Turn, allowBotTurn bool) error {
+	if err := validateBounds(turn.Cell); err != nil {
+		return err
+	}
+	if err := g.ValidateTurn(turn.Player, allowBotTurn); err != nil {
+		return err
+	}
+	if existing := g.board.PlayerAt(turn.Cell); existing > 0 {
+		return errs.InvalidTurn(fmt.Sprintf("cell[%d,%d,%d] already taken by player %d", turn.Layer, turn.Row,
+			turn.Column, existing))
+	}
+	return nil
+}
+
+// MustStart is a convenient shorthand for calling Start whilst panicking if it returns an error
+func MustStart(opts ...Option) Game {
+	if g, err := Start(opts...); err != nil {
+		panic(err)
+	} else {
+		return g
+	}
+}
+
+// Start returns a new Game, optionally customized by providing options.
+//
+// An ErrOptionInvalid is returned if an Option is passed that was given an invalid argument or if additional winning
+// Conditions are passed (e.g. WithCondition) while a Player is controlled by a built-in Bot that only considers the
+// standard winning Conditions (e.g. NewHeuristicBot).
+func Start(opts ...Option) (Game, error) {
+	g := &game{Base: variant.NewBase[Bot]()}
+
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(g.conditions) > 0 {
+		for _, bot := range g.Bots() {
+			if _, ok := bot.(*heuristicBot); ok {
+				return nil, errs.InvalidOption("WithCondition", fmt.Errorf("%q bot for player[%d] only considers "+
+					"standard conditions", bot.Name(), bot.Player()))
+			}
+		}
+	}
+	g.conditions = append(StandardConditions(), g.conditions...)
+	g.Start()
+	return g, nil
+}
+
+// Option is used to customize Game
+type Option func(g *game) error
+
+// WithBot customizes a Game so that the Player of the given Bot is controlled by it.
+//
+// A Game can have a Bot for each Player, allowing two bots to play each other. This option is ignored if preceded by
+// another bot-controlling option for the same Player (e.g. WithHeuristicBot).
+//
+// An ErrOptionInvalid is returned by the option if bot has an invalid Player.
+func WithBot(bot Bot) Option {
+	return withBot(bot, "WithBot")
+}
+
+// WithCondition customizes a Game to include an additional winning Condition.
+//
+// Start returns an ErrOptionInvalid if this option is used while a Player is controlled by a built-in Bot that only
+// considers the standard winning Conditions (e.g. NewHeuristicBot).
+func WithCondition(condition Condition) Option {
+	return func(g *game) error {
+		g.conditions = append(g.conditions, condition)
+		return nil
+	}
+}
+
+// WithConditions customizes a Game to include additional winning Conditions.
+//
+// Start returns an ErrOptionInvalid if this option is used while a Player is controlled by a built-in Bot that only
+// considers the standard winning Conditions (e.g. NewHeuristicBot).
+func WithConditions(conditions Conditions) Option {
+	return func(g *game) error {
+		g.conditions = append(g.conditions, conditions...)
+		return nil
+	}
+}
+
+// WithHeuristicBot is a convenient shorthand for WithBot(NewHeuristicBot(player)).
+//
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithRandomBot).
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithHeuristicBot(player tictactoe.Player) Option {
+	return withBot(NewHeuristicBot(player), "WithHeuristicBot")
+}
+
+// WithRand customizes a Game to use the given source of randomness, including by any built-in Bot.
+//
+// Game guards r so that it's safe for concurrent use, so r must no longer be used directly once Game has started.
+//
+// An ErrOptionInvalid is returned by the option if r is nil.
+func WithRand(r *rand.Rand) Option {
+	return func(g *game) error {
+		return g.SetRand(r, "WithRand")
+	}
+}
+
+// WithRandomBot is a convenient shorthand for WithBot(NewRandomBot(player)).
+//
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithHeuristicBot).
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithRandomBot(player tictactoe.Player) Option {
+	return withBot(NewRandomBot(player), "WithRandomBot")
+}
+
+// WithSeed customizes a Game to use a source of randomness with the given seed, including by any built-in Bot, so that
+// the same random decisions are made whenever the same seed is used
+func WithSeed(seed int64) Option {
+	return func(g *game) error {
+		g.SetSeed(seed)
+		return nil
+	}
+}
+
+// WithStarterPlayer customizes a Game to start with the given Player.
+//
+// This option is ignored if preceded by another WithStarterPlayer.
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithStarterPlayer(player tictactoe.Player) Option {
+	return func(g *game) error {
+		return g.SetStarter(player, "WithStarterPlayer")
+	}
+}
+
+func withBot(bot Bot, option string) Option {
+	return func(g *game) error {
+		return g.SetBot(bot, option)
+	}
+}
+
+// cellAt returns the Cell for the given index
+func cellAt(i int) Cell {
+	return Cell{
+		Column: uint8(i % int(Size)),
+		Layer:  uint8(i / (int(Size) * int(Size))),
+		Row:    uint8(i / int(Size) % int(Size)),
+	}
+}
+
+// index returns the index of the given Cell, where layers are followed by rows and then columns
+func index(cell Cell) int {
+	return (int(cell.Layer)*int(Size)+int(cell.Row))*int(Size) + int(cell.Column)
+}
+
+// isInBounds returns whether the given layer, row, and column are within Board
+func isInBounds(layer, row, col int) bool {
+	return layer >= 0 && layer < int(Size) && row >= 0 && row < int(Size) && col >= 0 && col < int(Size)
+}
+
+func validateBounds(cell Cell) error {
+	if cell.Layer >= Size || cell.Row >= Size || cell.Column >= Size {
+		return errs.OutOfBounds(fmt.Sprintf("layer[%d]row[%d]col[%d]", cell.Layer, cell.Row, cell.Column), Size)
+	}
+	return nil
+}
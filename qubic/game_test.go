@@ -0,0 +1,174 @@
+package qubic
+
+import (
+	"errors"
+	"github.com/neocotic/go-tic-tac-toe"
+	"sync"
+	"testing"
+)
+
+func TestStandardConditions(t *testing.T) {
+	if len(lines) != 76 {
+		t.Fatalf("lines has %d lines, want 76", len(lines))
+	}
+	seen := make(map[[Size]Cell]bool, len(lines))
+	for _, line := range lines {
+		if seen[line] {
+			t.Errorf("lines has duplicate line %v", line)
+		}
+		seen[line] = true
+	}
+
+	var board Board
+	for _, cell := range lines[len(lines)-1] {
+		board[cell.Layer][cell.Row][cell.Column] = tictactoe.PlayerTwo
+	}
+	winner, err := StandardConditions().FindWinner(board)
+	if err != nil {
+		t.Fatalf("FindWinner() returned unexpected error: %v", err)
+	}
+	if winner != tictactoe.PlayerTwo {
+		t.Errorf("FindWinner() = %v, want %v", winner, tictactoe.PlayerTwo)
+	}
+}
+
+func TestGame_Play(t *testing.T) {
+	g := MustStart()
+	for col := uint8(0); col < Size; col++ {
+		mustPlay(t, g, Cell{Column: col, Layer: col, Row: col})
+		if col < Size-1 {
+			mustPlay(t, g, Cell{Column: col, Layer: Size - 1})
+		}
+	}
+	if g.State() != tictactoe.StateWon || g.Player() != tictactoe.PlayerOne {
+		t.Errorf("Play() resulted in %v for player[%d], want %v for player[%d]", g.State(), g.Player(),
+			tictactoe.StateWon, tictactoe.PlayerOne)
+	}
+	if cells := g.WinningCells(); len(cells) != int(Size) || cells[0] != (Cell{}) {
+		t.Errorf("WinningCells() = %v, want space diagonal from %v", cells, Cell{})
+	}
+	_, _, err := g.Play(Turn{Cell: Cell{Row: 1}, Player: tictactoe.PlayerTwo})
+	if !errors.Is(err, tictactoe.ErrGameOver) {
+		t.Errorf("Play() returned error %v, want %v", err, tictactoe.ErrGameOver)
+	}
+}
+
+func TestGame_PlayInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		turn Turn
+		want error
+	}{
+		{"out of bounds", nil, Turn{Cell: Cell{Layer: Size}, Player: tictactoe.PlayerTwo}, tictactoe.ErrOutOfBounds},
+		{"player not found", nil, Turn{Player: 3}, tictactoe.ErrPlayerNotFound},
+		{"cell taken", nil, Turn{Player: tictactoe.PlayerTwo}, tictactoe.ErrTurnInvalid},
+		{"not turn of player", nil, Turn{Cell: Cell{Row: 1}, Player: tictactoe.PlayerOne}, tictactoe.ErrTurnInvalid},
+		{
+			name: "human for bot",
+			opts: []Option{WithRandomBot(tictactoe.PlayerTwo)},
+			turn: Turn{Cell: Cell{Row: 1}, Player: tictactoe.PlayerTwo},
+			want: tictactoe.ErrTurnInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(tc.opts...)
+			mustPlay(t, g, Cell{})
+			if _, _, err := g.Play(tc.turn); !errors.Is(err, tc.want) {
+				t.Errorf("Play() returned error %v, want %v", err, tc.want)
+			}
+			if got := len(g.Turns()); got != 1 {
+				t.Errorf("Play() resulted in %d turns, want 1", got)
+			}
+		})
+	}
+}
+
+func TestGame_Resign(t *testing.T) {
+	g := MustStart()
+	if _, _, err := g.Resign(3); !errors.Is(err, tictactoe.ErrPlayerNotFound) {
+		t.Errorf("Resign() returned error %v, want %v", err, tictactoe.ErrPlayerNotFound)
+	}
+	state, player, err := g.Resign(tictactoe.PlayerTwo)
+	if err != nil {
+		t.Fatalf("Resign() returned unexpected error: %v", err)
+	}
+	if state != tictactoe.StateForfeited || player != tictactoe.PlayerTwo {
+		t.Errorf("Resign() = %v, %v, want %v, %v", state, player, tictactoe.StateForfeited, tictactoe.PlayerTwo)
+	}
+	if _, _, err = g.Resign(tictactoe.PlayerOne); !errors.Is(err, tictactoe.ErrGameOver) {
+		t.Errorf("Resign() returned error %v, want %v", err, tictactoe.ErrGameOver)
+	}
+}
+
+func TestHeuristicBot(t *testing.T) {
+	for _, player := range tictactoe.Players() {
+		for seed := int64(1); seed <= 5; seed++ {
+			g := MustStart(WithHeuristicBot(player), WithRandomBot(player.Next()), WithSeed(seed))
+			for g.IsBotTurn() {
+				if _, _, err := g.AllowBotTurn(); err != nil {
+					t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+				}
+			}
+			if g.State() != tictactoe.StateWon || g.Player() != player {
+				t.Errorf("heuristic bot as player[%d] did not beat random bot with seed %d:\n%s", player, seed, g)
+			}
+		}
+	}
+}
+
+func TestStart_ConditionWithHeuristicBot(t *testing.T) {
+	condition := cornerCondition{}
+	_, err := Start(WithCondition(condition), WithHeuristicBot(tictactoe.PlayerTwo))
+	if !errors.Is(err, tictactoe.ErrOptionInvalid) {
+		t.Errorf("Start() returned error %v, want %v", err, tictactoe.ErrOptionInvalid)
+	}
+
+	g, err := Start(WithConditions(Conditions{condition}), WithRandomBot(tictactoe.PlayerTwo))
+	if err != nil {
+		t.Fatalf("Start() returned unexpected error: %v", err)
+	}
+	mustPlay(t, g, Cell{Column: Size - 1, Layer: Size - 1, Row: Size - 1})
+	if g.State() != tictactoe.StateWon || g.Player() != tictactoe.PlayerOne {
+		t.Errorf("Play() resulted in %v for player[%d], want %v for player[%d]", g.State(), g.Player(),
+			tictactoe.StateWon, tictactoe.PlayerOne)
+	}
+}
+
+func TestGame_RandConcurrent(t *testing.T) {
+	g := MustStart(WithRandomBot(tictactoe.PlayerOne), WithRandomBot(tictactoe.PlayerTwo), WithSeed(1))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = g.Rand().Intn(int(Size))
+		}
+	}()
+	for g.IsBotTurn() {
+		if _, _, err := g.AllowBotTurn(); err != nil {
+			t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+// cornerCondition is a Condition where a Player wins by taking the last corner of Board
+type cornerCondition struct{}
+
+func (c cornerCondition) FindWinner(board Board) tictactoe.Player {
+	return board[Size-1][Size-1][Size-1]
+}
+
+func (c cornerCondition) IsWinningTurn(board Board, turn Turn) bool {
+	corner := Cell{Column: Size - 1, Layer: Size - 1, Row: Size - 1}
+	return turn.Cell == corner && board.PlayerAt(corner) == turn.Player
+}
+
+// mustPlay plays the given Cell for the current Player of Game, failing the test if it returns an error
+func mustPlay(t *testing.T, g Game, cell Cell) {
+	t.Helper()
+	if _, _, err := g.Play(Turn{Cell: cell, Player: g.Player()}); err != nil {
+		t.Fatalf("Play() returned unexpected error: %v", err)
+	}
+}
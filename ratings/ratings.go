@@ -0,0 +1,305 @@
+package ratings
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe"
+	"math"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultKFactor is the default maximum adjustment of a rating following a single game
+	DefaultKFactor float64 = 32
+	// DefaultRating is the rating given to any player who has yet to finish a game
+	DefaultRating float64 = 1500
+	// StandardVariant is the variant of a standard Game, being played on an empty 3x3 Board, where a Player must occupy
+	// an entire row, column, or diagonal to win, without gravity or misère rules
+	StandardVariant = "3x3/k3"
+	// Version is the version of the JSON encoding of Ratings
+	Version = 2
+)
+
+var (
+	// ErrGameNotOver is returned if attempting to rate a Game that has StateAwaitingTurn
+	ErrGameNotOver = errors.New("game not over")
+	// ErrNameInvalid is returned if the name of a player is empty or the same as that of their opponent
+	ErrNameInvalid = errors.New("invalid name")
+	// ErrVersionUnsupported is returned if attempting to decode Ratings of an unsupported version
+	ErrVersionUnsupported = errors.New("unsupported version")
+)
+
+func fmtInvalidNameErr(player tictactoe.Player, name string) error {
+	return fmt.Errorf("%w: player[%d] %q", ErrNameInvalid, player, name)
+}
+
+type (
+	// Entry represents the rating of a single player (e.g. a Bot or a local profile of a human)
+	Entry struct {
+		// Draws is the number of games finished by the player in a draw
+		Draws int `json:"draws"`
+		// Losses is the number of games lost by the player, including where they resigned
+		Losses int `json:"losses"`
+		// Name is the name of the player, which is the name of the Bot for any Bot
+		Name string `json:"name"`
+		// Rating is the Elo rating of the player
+		Rating float64 `json:"rating"`
+		// Variant is the variant of the games finished by the player (e.g. StandardVariant)
+		Variant string `json:"variant"`
+		// Wins is the number of games won by the player, including where their opponent resigned
+		Wins int `json:"wins"`
+	}
+
+	// Ratings maintains the Elo rating of each player, typically persisted to a local file using Load and Save.
+	//
+	// Each variant (see Variant) has a separate pool of ratings so that a player's rating for one variant is not
+	// affected by how they play another.
+	//
+	// Ratings is not safe for concurrent use by multiple goroutines.
+	Ratings struct {
+		// KFactor is the maximum adjustment of a rating following a single game
+		KFactor float64
+		entries map[entryKey]*Entry
+	}
+
+	// entryKey identifies the Entry of a player within the pool of a variant
+	entryKey struct {
+		name, variant string
+	}
+
+	// ratingsJSON represents the JSON encoding of Ratings
+	ratingsJSON struct {
+		Entries []Entry `json:"entries"`
+		KFactor float64 `json:"kFactor"`
+		Version int     `json:"version"`
+	}
+)
+
+// Games returns the total number of games finished by the player
+func (e Entry) Games() int {
+	return e.Draws + e.Losses + e.Wins
+}
+
+// Get returns the Entry for the player with the given name within the pool of the given variant, which has
+// DefaultRating if they're yet to finish a game of that variant
+func (r *Ratings) Get(variant, name string) Entry {
+	if e, ok := r.entries[entryKey{name, variant}]; ok {
+		return *e
+	}
+	return Entry{Name: name, Rating: DefaultRating, Variant: variant}
+}
+
+// Leaderboard returns an Entry for each player within the pool of the given variant, ordered by rating (highest first)
+// and then by name
+func (r *Ratings) Leaderboard(variant string) []Entry {
+	var entries []Entry
+	for _, e := range r.entries {
+		if e.Variant == variant {
+			entries = append(entries, *e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if c := cmp.Compare(b.Rating, a.Rating); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+// MarshalJSON returns the JSON encoding of Ratings
+func (r *Ratings) MarshalJSON() ([]byte, error) {
+	entries := make([]Entry, 0, len(r.entries))
+	for _, variant := range r.Variants() {
+		entries = append(entries, r.Leaderboard(variant)...)
+	}
+	return json.Marshal(ratingsJSON{
+		Entries: entries,
+		KFactor: r.KFactor,
+		Version: Version,
+	})
+}
+
+// Record updates the ratings of the players with the given names within the pool of the given variant following a game
+// between them, where score is that of playerOne (i.e. 1 for a win, 0.5 for a draw, or 0 for a loss), and returns their
+// updated entries.
+//
+// An ErrNameInvalid is returned if either name is empty or both names are the same.
+func (r *Ratings) Record(variant, playerOne, playerTwo string, score float64) (Entry, Entry, error) {
+	if playerOne == "" {
+		return Entry{}, Entry{}, fmtInvalidNameErr(tictactoe.PlayerOne, playerOne)
+	}
+	if playerTwo == "" || playerTwo == playerOne {
+		return Entry{}, Entry{}, fmtInvalidNameErr(tictactoe.PlayerTwo, playerTwo)
+	}
+	one, two := r.entry(variant, playerOne), r.entry(variant, playerTwo)
+	expected := 1 / (1 + math.Pow(10, (two.Rating-one.Rating)/400))
+	delta := r.KFactor * (score - expected)
+	one.Rating += delta
+	two.Rating -= delta
+	switch {
+	case score > 0.5:
+		one.Wins++
+		two.Losses++
+	case score < 0.5:
+		one.Losses++
+		two.Wins++
+	default:
+		one.Draws++
+		two.Draws++
+	}
+	return *one, *two, nil
+}
+
+// Save writes the JSON encoding of Ratings to the file at the given path, replacing any existing file
+func (r *Ratings) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// UnmarshalJSON decodes the JSON encoding of Ratings as produced by MarshalJSON, where every Entry of version 1 is
+// within the pool of StandardVariant as only standard games could be rated.
+//
+// An ErrVersionUnsupported is returned if the version of data is not supported (i.e. less than 1 or greater than
+// Version).
+func (r *Ratings) UnmarshalJSON(data []byte) error {
+	var rj ratingsJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return err
+	}
+	if rj.Version < 1 || rj.Version > Version {
+		return fmt.Errorf("%w: %d", ErrVersionUnsupported, rj.Version)
+	}
+	r.KFactor = rj.KFactor
+	if r.KFactor <= 0 {
+		r.KFactor = DefaultKFactor
+	}
+	r.entries = make(map[entryKey]*Entry, len(rj.Entries))
+	for i := range rj.Entries {
+		e := &rj.Entries[i]
+		if rj.Version == 1 {
+			e.Variant = StandardVariant
+		}
+		r.entries[entryKey{e.Name, e.Variant}] = e
+	}
+	return nil
+}
+
+// Update updates the ratings of both players of the given Game, which must be over, within the pool of its variant (see
+// Variant) and returns their updated entries.
+//
+// The name of each Player is the given name for them or, where empty, the name of the Bot controlling them.
+//
+// An error is returned in following cases:
+//   - ErrGameNotOver if game has StateAwaitingTurn
+//   - ErrNameInvalid if the name of either Player is empty or both names are the same
+func (r *Ratings) Update(game tictactoe.Game, playerOne, playerTwo string) (Entry, Entry, error) {
+	var score float64
+	switch state, player := game.State(), game.Player(); state {
+	case tictactoe.StateDraw:
+		score = 0.5
+	case tictactoe.StateForfeited, tictactoe.StateWon:
+		// Player is the resigner for StateForfeited and the winner for StateWon
+		if (player == tictactoe.PlayerOne) == (state == tictactoe.StateWon) {
+			score = 1
+		}
+	default:
+		return Entry{}, Entry{}, ErrGameNotOver
+	}
+	if b := game.Bot(tictactoe.PlayerOne); b != nil && playerOne == "" {
+		playerOne = b.Name()
+	}
+	if b := game.Bot(tictactoe.PlayerTwo); b != nil && playerTwo == "" {
+		playerTwo = b.Name()
+	}
+	return r.Record(Variant(game), playerOne, playerTwo, score)
+}
+
+// Variants returns the variant of every pool containing at least one player, with StandardVariant first followed by the
+// others in alphabetical order
+func (r *Ratings) Variants() []string {
+	var variants []string
+	for key := range r.entries {
+		if !slices.Contains(variants, key.variant) {
+			variants = append(variants, key.variant)
+		}
+	}
+	slices.SortFunc(variants, func(a, b string) int {
+		if (a == StandardVariant) != (b == StandardVariant) {
+			if a == StandardVariant {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return variants
+}
+
+func (r *Ratings) entry(variant, name string) *Entry {
+	if r.entries == nil {
+		r.entries = make(map[entryKey]*Entry)
+	}
+	key := entryKey{name, variant}
+	e, ok := r.entries[key]
+	if !ok {
+		e = &Entry{Name: name, Rating: DefaultRating, Variant: variant}
+		r.entries[key] = e
+	}
+	return e
+}
+
+// Load returns Ratings decoded from the file at the given path, or new Ratings if the file does not exist.
+//
+// An ErrVersionUnsupported is returned if the file contains Ratings of an unsupported version.
+func Load(path string) (*Ratings, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	r := New()
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// New returns new Ratings without any players using DefaultKFactor
+func New() *Ratings {
+	return &Ratings{
+		KFactor: DefaultKFactor,
+		entries: make(map[entryKey]*Entry),
+	}
+}
+
+// Variant returns the variant of the given Game, which identifies the pool of ratings for its players, being the size
+// of its Board and the number of cells in a row required to win (e.g. "4x5/k4"), followed by any rules that change how
+// it's played (e.g. "/custom" where it has additional winning Conditions, "/gravity", "/misere", or "/position" where
+// it started from a Board that already had turns).
+//
+// The variant of a standard Game is StandardVariant.
+func Variant(game tictactoe.Game) string {
+	variant := fmt.Sprintf("%dx%d/k%d", game.Rows(), game.Columns(), game.WinLength())
+	if len(game.Conditions()) > len(tictactoe.StandardConditions(game.WinLength())) {
+		variant += "/custom"
+	}
+	if game.HasGravity() {
+		variant += "/gravity"
+	}
+	if game.IsMisere() {
+		variant += "/misere"
+	}
+	if game.Snapshot().Board != nil {
+		variant += "/position"
+	}
+	return variant
+}
@@ -0,0 +1,295 @@
+package ratings
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/neocotic/go-tic-tac-toe"
+	"math"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRatings_Leaderboard(t *testing.T) {
+	r := New()
+	for _, game := range []struct {
+		playerOne, playerTwo string
+		score                float64
+	}{
+		{"alice", "easy", 1},
+		{"bob", "easy", 0.5},
+		{"carol", "hard", 0},
+	} {
+		if _, _, err := r.Record(StandardVariant, game.playerOne, game.playerTwo, game.score); err != nil {
+			t.Fatalf("Record() returned unexpected error: %v", err)
+		}
+	}
+
+	if _, _, err := r.Record("4x4/k4", "carol", "hard", 1); err != nil {
+		t.Fatalf("Record() returned unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, e := range r.Leaderboard(StandardVariant) {
+		names = append(names, e.Name)
+	}
+	if want := []string{"alice", "hard", "bob", "easy", "carol"}; !slices.Equal(names, want) {
+		t.Errorf("Leaderboard() names = %v, want %v", names, want)
+	}
+	if got := r.Leaderboard("4x4/k4"); len(got) != 2 || got[0].Name != "carol" || got[0].Rating != 1516 {
+		t.Errorf("Leaderboard() = %+v, want carol first with rating unaffected by other variants", got)
+	}
+	if got, want := r.Variants(), []string{StandardVariant, "4x4/k4"}; !slices.Equal(got, want) {
+		t.Errorf("Variants() = %v, want %v", got, want)
+	}
+}
+
+func TestRatings_Record(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		ratingOne        float64
+		ratingTwo        float64
+		score            float64
+		wantOne, wantTwo float64
+	}{
+		{"equal win", DefaultRating, DefaultRating, 1, 1516, 1484},
+		{"equal draw", DefaultRating, DefaultRating, 0.5, 1500, 1500},
+		{"equal loss", DefaultRating, DefaultRating, 0, 1484, 1516},
+		{"underdog win", 1400, 1800, 1, 1429.09, 1770.91},
+		{"favourite draw", 1800, 1400, 0.5, 1786.91, 1413.09},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New()
+			r.entry(StandardVariant, "one").Rating = tc.ratingOne
+			r.entry(StandardVariant, "two").Rating = tc.ratingTwo
+			one, two, err := r.Record(StandardVariant, "one", "two", tc.score)
+			if err != nil {
+				t.Fatalf("Record() returned unexpected error: %v", err)
+			}
+			if math.Abs(one.Rating-tc.wantOne) > 0.01 || math.Abs(two.Rating-tc.wantTwo) > 0.01 {
+				t.Errorf("Record() ratings = %.2f, %.2f, want %.2f, %.2f", one.Rating, two.Rating, tc.wantOne,
+					tc.wantTwo)
+			}
+			if one.Games() != 1 || two.Games() != 1 {
+				t.Errorf("Record() games = %d, %d, want 1, 1", one.Games(), two.Games())
+			}
+			if got := r.Get(StandardVariant, "one"); got != one {
+				t.Errorf("Get() = %+v, want %+v", got, one)
+			}
+		})
+	}
+}
+
+func TestRatings_Record_InvalidName(t *testing.T) {
+	for _, tc := range []struct {
+		name                 string
+		playerOne, playerTwo string
+	}{
+		{"empty one", "", "two"},
+		{"empty two", "one", ""},
+		{"same", "one", "one"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New()
+			if _, _, err := r.Record(StandardVariant, tc.playerOne, tc.playerTwo, 1); !errors.Is(err, ErrNameInvalid) {
+				t.Errorf("Record() returned error %v, want %v", err, ErrNameInvalid)
+			}
+			if len(r.Variants()) != 0 {
+				t.Error("Record() changed ratings despite returning error")
+			}
+		})
+	}
+}
+
+func TestRatings_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.json")
+	r, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+	if len(r.Variants()) != 0 {
+		t.Errorf("Load() of missing file returned variants %v, want none", r.Variants())
+	}
+
+	r.KFactor = 16
+	if _, _, err = r.Record(StandardVariant, "alice", "easy", 1); err != nil {
+		t.Fatalf("Record() returned unexpected error: %v", err)
+	}
+	if _, _, err = r.Record("4x4/k4/gravity", "alice", "mcts", 0); err != nil {
+		t.Fatalf("Record() returned unexpected error: %v", err)
+	}
+	if err = r.Save(path); err != nil {
+		t.Fatalf("Save() returned unexpected error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+	if loaded.KFactor != r.KFactor {
+		t.Errorf("Load() has KFactor %v, want %v", loaded.KFactor, r.KFactor)
+	}
+	for _, variant := range r.Variants() {
+		if got, want := loaded.Leaderboard(variant), r.Leaderboard(variant); !slices.Equal(got, want) {
+			t.Errorf("Load() has Leaderboard(%q) %+v, want %+v", variant, got, want)
+		}
+	}
+}
+
+func TestRatings_UnmarshalJSON_Version1(t *testing.T) {
+	var r Ratings
+	data := []byte(`{"entries":[{"draws":0,"losses":0,"name":"alice","rating":1516,"wins":1}],"kFactor":32,` +
+		`"version":1}`)
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("UnmarshalJSON() returned unexpected error: %v", err)
+	}
+	if got := r.Get(StandardVariant, "alice"); got.Rating != 1516 || got.Wins != 1 {
+		t.Errorf("UnmarshalJSON() has Get() %+v, want version 1 entry within %q", got, StandardVariant)
+	}
+}
+
+func TestRatings_UnmarshalJSON_UnsupportedVersion(t *testing.T) {
+	var r Ratings
+	if err := json.Unmarshal([]byte(`{"entries":[],"kFactor":32,"version":3}`), &r); !errors.Is(err,
+		ErrVersionUnsupported) {
+		t.Errorf("UnmarshalJSON() returned error %v, want %v", err, ErrVersionUnsupported)
+	}
+}
+
+func TestRatings_Update(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cells   []string
+		resign  tictactoe.Player
+		wantOne Entry
+		wantTwo Entry
+	}{
+		{
+			name:    "won",
+			cells:   []string{"a1", "a2", "b1", "b2", "c1"},
+			wantOne: Entry{Name: "alice", Rating: 1516, Variant: StandardVariant, Wins: 1},
+			wantTwo: Entry{Losses: 1, Name: "bob", Rating: 1484, Variant: StandardVariant},
+		},
+		{
+			name:    "draw",
+			cells:   []string{"b2", "a1", "c1", "a3", "a2", "c2", "b1", "b3", "c3"},
+			wantOne: Entry{Draws: 1, Name: "alice", Rating: 1500, Variant: StandardVariant},
+			wantTwo: Entry{Draws: 1, Name: "bob", Rating: 1500, Variant: StandardVariant},
+		},
+		{
+			name:    "forfeited",
+			cells:   []string{"b2"},
+			resign:  tictactoe.PlayerOne,
+			wantOne: Entry{Losses: 1, Name: "alice", Rating: 1484, Variant: StandardVariant},
+			wantTwo: Entry{Name: "bob", Rating: 1516, Variant: StandardVariant, Wins: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := tictactoe.MustStart()
+			for _, coords := range tc.cells {
+				cell, err := tictactoe.ParseCell(coords)
+				if err != nil {
+					t.Fatalf("ParseCell() returned unexpected error: %v", err)
+				}
+				if _, _, err = g.Play(tictactoe.Turn{Cell: cell, Player: g.Player()}); err != nil {
+					t.Fatalf("Play() returned unexpected error: %v", err)
+				}
+			}
+			if tc.resign > 0 {
+				if _, _, err := g.Resign(tc.resign); err != nil {
+					t.Fatalf("Resign() returned unexpected error: %v", err)
+				}
+			}
+
+			one, two, err := New().Update(g, "alice", "bob")
+			if err != nil {
+				t.Fatalf("Update() returned unexpected error: %v", err)
+			}
+			if one != tc.wantOne || two != tc.wantTwo {
+				t.Errorf("Update() = %+v, %+v, want %+v, %+v", one, two, tc.wantOne, tc.wantTwo)
+			}
+		})
+	}
+}
+
+func TestRatings_Update_Bot(t *testing.T) {
+	g := tictactoe.MustStart(tictactoe.WithEasyBot(tictactoe.PlayerTwo))
+	if _, _, err := g.Resign(tictactoe.PlayerTwo); err != nil {
+		t.Fatalf("Resign() returned unexpected error: %v", err)
+	}
+	one, two, err := New().Update(g, "alice", "")
+	if err != nil {
+		t.Fatalf("Update() returned unexpected error: %v", err)
+	}
+	if one.Name != "alice" || two.Name != "easy" {
+		t.Errorf("Update() names = %q, %q, want %q, %q", one.Name, two.Name, "alice", "easy")
+	}
+}
+
+func TestRatings_Update_NotOver(t *testing.T) {
+	r := New()
+	g := tictactoe.MustStart(tictactoe.WithEasyBot(tictactoe.PlayerTwo))
+	if _, _, err := r.Update(g, "alice", ""); !errors.Is(err, ErrGameNotOver) {
+		t.Errorf("Update() returned error %v, want %v", err, ErrGameNotOver)
+	}
+	if len(r.Variants()) != 0 {
+		t.Error("Update() changed ratings despite returning error")
+	}
+}
+
+func TestRatings_Update_Variant(t *testing.T) {
+	r := New()
+	for _, opts := range [][]tictactoe.Option{nil, {tictactoe.WithGravity(), tictactoe.WithSize(4)}} {
+		g := tictactoe.MustStart(append([]tictactoe.Option{tictactoe.WithEasyBot(tictactoe.PlayerTwo)}, opts...)...)
+		if _, _, err := g.Resign(tictactoe.PlayerTwo); err != nil {
+			t.Fatalf("Resign() returned unexpected error: %v", err)
+		}
+		one, _, err := r.Update(g, "alice", "")
+		if err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+		if want := Variant(g); one.Variant != want || one.Games() != 1 {
+			t.Errorf("Update() = %+v, want first game within %q", one, want)
+		}
+	}
+}
+
+func TestVariant(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []tictactoe.Option
+		want string
+	}{
+		{"standard", nil, StandardVariant},
+		{"dimensions", []tictactoe.Option{tictactoe.WithDimensions(4, 5), tictactoe.WithWinLength(4)}, "4x5/k4"},
+		{"custom", []tictactoe.Option{tictactoe.WithCondition(cornerCondition{})}, "3x3/k3/custom"},
+		{"gravity", []tictactoe.Option{tictactoe.WithGravity(), tictactoe.WithSize(4)}, "4x4/k4/gravity"},
+		{"misere", []tictactoe.Option{tictactoe.WithMisere()}, "3x3/k3/misere"},
+		{"position", []tictactoe.Option{tictactoe.WithPosition("x../.../... o")}, "3x3/k3/position"},
+		{
+			name: "all",
+			opts: []tictactoe.Option{
+				tictactoe.WithGravity(),
+				tictactoe.WithMisere(),
+				tictactoe.WithPosition("..../..../..../x... o"),
+			},
+			want: "4x4/k4/gravity/misere/position",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Variant(tictactoe.MustStart(tc.opts...)); got != tc.want {
+				t.Errorf("Variant() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// cornerCondition is a tictactoe.Condition where a Player wins by taking the top-left corner of the Board
+type cornerCondition struct{}
+
+func (c cornerCondition) FindWinner(board tictactoe.Board) tictactoe.Player {
+	return board[0][0]
+}
+
+func (c cornerCondition) IsWinningTurn(board tictactoe.Board, turn tictactoe.Turn) bool {
+	return turn.Cell == tictactoe.Cell{} && board[0][0] == turn.Player
+}
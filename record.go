@@ -0,0 +1,453 @@
+package tictactoe
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RecordDateLayout is the layout of the date within the text of a Record
+	RecordDateLayout = "2006.01.02"
+	// RecordUnknown is used within the text of a Record to represent an unknown value (e.g. the name of a human Player)
+	RecordUnknown = "?"
+)
+
+const (
+	recordTagColumns   = "Columns"
+	recordTagDate      = "Date"
+	recordTagPlayerOne = "PlayerOne"
+	recordTagPlayerTwo = "PlayerTwo"
+	recordTagPosition  = "Position"
+	recordTagResult    = "Result"
+	recordTagRows      = "Rows"
+	recordTagStarter   = "Starter"
+	recordTagVariant   = "Variant"
+	recordTagWinLength = "WinLength"
+
+	recordVariantGravity = "gravity"
+	recordVariantMisere  = "misere"
+
+	// recordLineLength is the maximum length of each line of moves within the text of a Record
+	recordLineLength = 80
+)
+
+type (
+	// Record represents a human-readable record of a Game, similar to Portable Game Notation (PGN) used for chess.
+	//
+	// The text of a Record (see Record.String) contains headers, describing the Game, followed by numbered moves in
+	// algebraic coordinates (see FormatCell) and, finally, the Result. For example;
+	//
+	//	[Date "2025.03.01"]
+	//	[PlayerOne "?"]
+	//	[PlayerTwo "hard"]
+	//	[Rows "3"]
+	//	[Columns "3"]
+	//	[WinLength "3"]
+	//	[Starter "x"]
+	//	[Result "1-0"]
+	//
+	//	1. b2 a1 2. c3 a3 3. a2 b1 4. c2 1-0
+	Record struct {
+		// Columns is the number of columns on the Board
+		Columns uint8
+		// Date is the date on which the Game was played, which is zero if unknown
+		Date time.Time
+		// Gravity is whether the Game was played with gravity (see WithGravity), which is recorded within the Variant
+		// header
+		Gravity bool
+		// Misere is whether the Game was played under misère rules (see WithMisere), which is recorded within the
+		// Variant header
+		Misere bool
+		// PlayerOne is the name of PlayerOne (e.g. the name of a Bot), which is empty if unknown
+		PlayerOne string
+		// PlayerTwo is the name of PlayerTwo (e.g. the name of a Bot), which is empty if unknown
+		PlayerTwo string
+		// Position is the compact notation (see Format) of the position on which the first of Turns was played, where
+		// the Game was started using WithBoard or WithPosition.
+		//
+		// Position is empty if the Game was started with an empty Board.
+		Position string
+		// Result is the result of the Game
+		Result Result
+		// Rows is the number of rows on the Board
+		Rows uint8
+		// Starter is the Player who was to take the first of Turns, which is zero if the Game was over when started
+		Starter Player
+		// Turns contains each Turn played in order
+		Turns []Turn
+		// WinLength is the number of consecutive cells a Player must occupy to win
+		WinLength uint8
+	}
+
+	// Result represents the result of a Game within a Record
+	Result string
+)
+
+const (
+	// ResultDraw represents a Game that ended with StateDraw
+	ResultDraw Result = "1/2-1/2"
+	// ResultOngoing represents a Game that is not yet over
+	ResultOngoing Result = "*"
+	// ResultPlayerOneWins represents a Game won by PlayerOne, including where PlayerTwo resigned
+	ResultPlayerOneWins Result = "1-0"
+	// ResultPlayerTwoWins represents a Game won by PlayerTwo, including where PlayerOne resigned
+	ResultPlayerTwoWins Result = "0-1"
+)
+
+// IsValid returns whether Result is valid
+func (r Result) IsValid() bool {
+	switch r {
+	case ResultDraw, ResultOngoing, ResultPlayerOneWins, ResultPlayerTwoWins:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the text of Record, which can be parsed back into a Record using ParseRecord
+func (r Record) String() string {
+	var sb strings.Builder
+	writeTag := func(name, value string) {
+		_, _ = fmt.Fprintf(&sb, "[%s %s]\n", name, strconv.Quote(value))
+	}
+
+	date := "????.??.??"
+	if !r.Date.IsZero() {
+		date = r.Date.Format(RecordDateLayout)
+	}
+	writeTag(recordTagDate, date)
+	writeTag(recordTagPlayerOne, formatRecordName(r.PlayerOne))
+	writeTag(recordTagPlayerTwo, formatRecordName(r.PlayerTwo))
+	writeTag(recordTagRows, strconv.Itoa(int(r.Rows)))
+	writeTag(recordTagColumns, strconv.Itoa(int(r.Columns)))
+	writeTag(recordTagWinLength, strconv.Itoa(int(r.WinLength)))
+	var variants []string
+	if r.Gravity {
+		variants = append(variants, recordVariantGravity)
+	}
+	if r.Misere {
+		variants = append(variants, recordVariantMisere)
+	}
+	if len(variants) > 0 {
+		writeTag(recordTagVariant, strings.Join(variants, " "))
+	}
+	if r.Starter > 0 {
+		writeTag(recordTagStarter, string(formatNotationPlayer(r.Starter)))
+	} else {
+		writeTag(recordTagStarter, notationGameOver)
+	}
+	if r.Position != "" {
+		writeTag(recordTagPosition, r.Position)
+	}
+	writeTag(recordTagResult, string(r.Result))
+	sb.WriteRune('\n')
+
+	var line strings.Builder
+	writeToken := func(token string) {
+		if line.Len() > 0 && line.Len()+1+len(token) > recordLineLength {
+			sb.WriteString(line.String())
+			sb.WriteRune('\n')
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteRune(' ')
+		}
+		line.WriteString(token)
+	}
+	for i, turn := range r.Turns {
+		if i%2 == 0 {
+			writeToken(strconv.Itoa(i/2+1) + ".")
+		}
+		writeToken(FormatCell(turn.Cell))
+	}
+	writeToken(string(r.Result))
+	sb.WriteString(line.String())
+	sb.WriteRune('\n')
+	return sb.String()
+}
+
+// NewRecord returns a new Record of the given Game, dated now.
+//
+// The name of each Player is the name of the Bot playing for them, where applicable. Otherwise, it's left empty so that
+// it can be populated by the caller.
+func NewRecord(game Game) Record {
+	snapshot := game.Snapshot()
+	r := Record{
+		Columns:   snapshot.Columns,
+		Date:      time.Now(),
+		Gravity:   snapshot.Gravity,
+		Misere:    snapshot.Misere,
+		Result:    gameResult(snapshot.State, snapshot.Player),
+		Rows:      snapshot.Rows,
+		Starter:   snapshot.Starter,
+		Turns:     snapshot.Turns,
+		WinLength: snapshot.WinLength,
+	}
+	if snapshot.Board != nil {
+		r.Position = formatPosition(snapshot.Board, snapshot.Starter)
+	}
+	for _, b := range snapshot.Bots {
+		switch b.Player {
+		case PlayerOne:
+			r.PlayerOne = b.Name
+		case PlayerTwo:
+			r.PlayerTwo = b.Name
+		}
+	}
+	return r
+}
+
+// ParseRecord returns the Record represented by the given text (see Record.String).
+//
+// Any unknown header is ignored and move numbers are optional, however, moves are assumed to alternate between each
+// Player starting with Starter. Record is not validated against the rules of a Game, which requires Replay.
+//
+// An ErrNotationInvalid is returned if text is malformed.
+func ParseRecord(text string) (Record, error) {
+	r, err := parseRecord(text)
+	if err != nil {
+		return Record{}, fmtInvalidNotationErr(err)
+	}
+	return r, nil
+}
+
+// Replay returns a new Game started using the given Record, whose turns are all played in order using Game.Play,
+// optionally customized by providing options.
+//
+// Turns within record are validated by playing them on a Game started without options so that, for example, a Bot can
+// be passed to continue an ongoing Game even though it's for a Player who took any of the turns. Where options are
+// provided, the returned Game is then started using them and restored to the same position.
+//
+// An error is returned in following cases:
+//   - ErrNotationInvalid if the Result of record does not match the result of playing all turns
+//   - ErrTurnInvalid, including the number of the offending move, if any Turn cannot be played
+//   - Otherwise, the same errors as Start may be returned
+func Replay(record Record, opts ...Option) (Game, error) {
+	pack := Pack{withRecord(record)}
+	if record.Position != "" {
+		pack = append(pack, WithPosition(record.Position))
+	} else {
+		pack = append(pack, WithDimensions(record.Rows, record.Columns))
+	}
+	if record.WinLength > 0 {
+		pack = append(pack, WithWinLength(record.WinLength))
+	}
+	if record.Gravity {
+		pack = append(pack, WithGravity())
+	}
+	if record.Misere {
+		pack = append(pack, WithMisere())
+	}
+	game, err := Start(WithPack(pack))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, turn := range record.Turns {
+		if _, _, err = game.Play(turn); err != nil {
+			move := strconv.Itoa(i/2+1) + "."
+			if i%2 == 1 {
+				move += ".."
+			}
+			if errors.Is(err, ErrTurnInvalid) {
+				return nil, fmt.Errorf("move %s %s: %w", move, FormatCell(turn.Cell), err)
+			}
+			return nil, fmt.Errorf("%w: move %s %s: %w", ErrTurnInvalid, move, FormatCell(turn.Cell), err)
+		}
+	}
+
+	if game.State() == StateAwaitingTurn {
+		switch record.Result {
+		case ResultPlayerOneWins:
+			_, _, err = game.Resign(PlayerTwo)
+		case ResultPlayerTwoWins:
+			_, _, err = game.Resign(PlayerOne)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if result := gameResult(game.State(), game.Player()); result != record.Result {
+		return nil, fmtInvalidNotationErr(fmt.Errorf("result %q does not match result of moves %q", record.Result,
+			result))
+	}
+	if len(opts) == 0 {
+		return game, nil
+	}
+	return Start(append(append([]Option{}, opts...), WithPack(pack), withReplay(game.Snapshot()))...)
+}
+
+func formatRecordName(name string) string {
+	if name == "" {
+		return RecordUnknown
+	}
+	return name
+}
+
+func gameResult(state State, player Player) Result {
+	switch state {
+	case StateDraw:
+		return ResultDraw
+	case StateForfeited:
+		player = player.Next()
+		fallthrough
+	case StateWon:
+		if player == PlayerOne {
+			return ResultPlayerOneWins
+		}
+		return ResultPlayerTwoWins
+	default:
+		return ResultOngoing
+	}
+}
+
+func parseRecord(text string) (Record, error) {
+	var r Record
+	var moves []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "[") {
+			moves = append(moves, strings.Fields(line)...)
+			continue
+		}
+		if len(moves) > 0 {
+			return r, fmt.Errorf("header must precede moves: %s", line)
+		}
+		if err := parseRecordTag(&r, line); err != nil {
+			return r, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return r, err
+	}
+
+	player := r.Starter
+	for i, move := range moves {
+		if result := Result(move); result.IsValid() {
+			if i != len(moves)-1 {
+				return r, fmt.Errorf("result must be last: %s", move)
+			}
+			if r.Result == "" {
+				r.Result = result
+			} else if r.Result != result {
+				return r, fmt.Errorf("result %q does not match header %q", result, r.Result)
+			}
+			continue
+		}
+		if number := strings.TrimRight(move, "."); number != move {
+			if _, err := strconv.ParseUint(number, 10, 32); err != nil {
+				return r, fmt.Errorf("invalid move number: %s", move)
+			}
+			continue
+		}
+		if player == 0 {
+			return r, errors.New("moves not allowed without starter")
+		}
+		cell, err := ParseCell(move)
+		if err != nil {
+			return r, fmt.Errorf("move[%d]: %w", len(r.Turns), err)
+		}
+		r.Turns = append(r.Turns, Turn{Cell: cell, Player: player})
+		player = player.Next()
+	}
+	if r.Result == "" {
+		r.Result = ResultOngoing
+	}
+	return r, nil
+}
+
+func parseRecordTag(r *Record, line string) error {
+	name, value, found := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"), " ")
+	if !found || !strings.HasSuffix(line, "]") {
+		return fmt.Errorf("malformed header: %s", line)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("malformed header: %s", line)
+	}
+
+	parseUint8 := func() (uint8, error) {
+		n, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s header: %q", name, value)
+		}
+		return uint8(n), nil
+	}
+
+	switch name {
+	case recordTagColumns:
+		r.Columns, err = parseUint8()
+	case recordTagDate:
+		if !strings.Contains(value, RecordUnknown) {
+			if r.Date, err = time.Parse(RecordDateLayout, value); err != nil {
+				err = fmt.Errorf("invalid %s header: %q", name, value)
+			}
+		}
+	case recordTagPlayerOne:
+		if value != RecordUnknown {
+			r.PlayerOne = value
+		}
+	case recordTagPlayerTwo:
+		if value != RecordUnknown {
+			r.PlayerTwo = value
+		}
+	case recordTagPosition:
+		r.Position = value
+	case recordTagResult:
+		if r.Result = Result(value); !r.Result.IsValid() {
+			err = fmt.Errorf("invalid %s header: %q", name, value)
+		}
+	case recordTagRows:
+		r.Rows, err = parseUint8()
+	case recordTagStarter:
+		switch strings.ToLower(value) {
+		case string(notationPlayerOne):
+			r.Starter = PlayerOne
+		case string(notationPlayerTwo):
+			r.Starter = PlayerTwo
+		case notationGameOver:
+			r.Starter = 0
+		default:
+			err = fmt.Errorf("invalid %s header: %q", name, value)
+		}
+	case recordTagVariant:
+		for _, variant := range strings.Fields(strings.ToLower(value)) {
+			switch variant {
+			case recordVariantGravity:
+				r.Gravity = true
+			case recordVariantMisere:
+				r.Misere = true
+			default:
+				err = fmt.Errorf("unknown variant in %s header: %q", name, variant)
+			}
+		}
+	case recordTagWinLength:
+		r.WinLength, err = parseUint8()
+	}
+	return err
+}
+
+// withRecord returns an Option that ensures Game is started with the Starter of the given Record, regardless of any
+// player-controlling option
+func withRecord(record Record) Option {
+	return func(g *game) error {
+		if record.Starter > 0 {
+			g.player = record.Starter
+		}
+		return nil
+	}
+}
+
+// withReplay returns an Option that restores the turns and state of the given Snapshot, taken from a Game started
+// using the same options that precede it, ignoring any Bot within it
+func withReplay(snapshot Snapshot) Option {
+	return func(g *game) error {
+		g.restore = &snapshot
+		return nil
+	}
+}
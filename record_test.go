@@ -0,0 +1,228 @@
+package tictactoe
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		opts   []Option
+		cells  []string
+		resign Player
+		result Result
+	}{
+		{
+			name:   "won",
+			cells:  []string{"b2", "a1", "c3", "a3", "a2", "b1", "c2"},
+			result: ResultPlayerOneWins,
+		},
+		{
+			name:   "draw",
+			cells:  []string{"b2", "a1", "c1", "a3", "a2", "c2", "b1", "b3", "c3"},
+			result: ResultDraw,
+		},
+		{
+			name:   "forfeited",
+			cells:  []string{"b2"},
+			resign: PlayerTwo,
+			result: ResultPlayerOneWins,
+		},
+		{
+			name:   "ongoing",
+			opts:   []Option{WithGravity(), WithMisere(), WithDimensions(4, 5), WithWinLength(3)},
+			cells:  []string{"a4", "b4", "a3"},
+			result: ResultOngoing,
+		},
+		{
+			name:   "position",
+			opts:   []Option{WithPosition("xo./.../... x")},
+			cells:  []string{"b2", "c3"},
+			result: ResultOngoing,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(tc.opts...)
+			for _, coords := range tc.cells {
+				if _, _, err := g.Play(Turn{Cell: mustParseCell(t, coords), Player: g.Player()}); err != nil {
+					t.Fatalf("Play() returned unexpected error: %v", err)
+				}
+			}
+			if tc.resign > 0 {
+				if _, _, err := g.Resign(tc.resign); err != nil {
+					t.Fatalf("Resign() returned unexpected error: %v", err)
+				}
+			}
+
+			record := NewRecord(g)
+			if record.Result != tc.result {
+				t.Errorf("NewRecord() has Result %q, want %q", record.Result, tc.result)
+			}
+			text := record.String()
+			parsed, err := ParseRecord(text)
+			if err != nil {
+				t.Fatalf("ParseRecord() returned unexpected error: %v", err)
+			}
+			if got, want := parsed.Date.Format(RecordDateLayout), record.Date.Format(RecordDateLayout); got != want {
+				t.Errorf("ParseRecord() has Date %s, want %s", got, want)
+			}
+			parsed.Date = record.Date
+			if !equalRecords(parsed, record) {
+				t.Errorf("ParseRecord() = %+v, want %+v", parsed, record)
+			}
+
+			replayed, err := Replay(parsed)
+			if err != nil {
+				t.Fatalf("Replay() returned unexpected error: %v", err)
+			}
+			if got, want := Format(replayed), Format(g); got != want {
+				t.Errorf("Format(Replay()) = %q, want %q", got, want)
+			}
+			if got, want := replayed.State(), g.State(); got != want {
+				t.Errorf("Replay() has State %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRecord_String(t *testing.T) {
+	record := Record{
+		Columns:   3,
+		PlayerTwo: "hard",
+		Result:    ResultPlayerOneWins,
+		Rows:      3,
+		Starter:   PlayerOne,
+		WinLength: 3,
+	}
+	for i, coords := range []string{"b2", "a1", "c3", "a3", "a2", "b1", "c2"} {
+		record.Turns = append(record.Turns, Turn{Cell: mustParseCell(t, coords), Player: Player(i%2 + 1)})
+	}
+	const want = `[Date "????.??.??"]
+[PlayerOne "?"]
+[PlayerTwo "hard"]
+[Rows "3"]
+[Columns "3"]
+[WinLength "3"]
+[Starter "x"]
+[Result "1-0"]
+
+1. b2 a1 2. c3 a3 3. a2 b1 4. c2 1-0
+`
+	if got := record.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestReplay_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		text    string
+		target  error
+		message string
+	}{
+		{
+			name:    "occupied",
+			text:    "[Rows \"3\"]\n[Columns \"3\"]\n[Starter \"x\"]\n\n1. b2 b2 *\n",
+			target:  ErrTurnInvalid,
+			message: "move 1... b2",
+		},
+		{
+			name:    "out of bounds",
+			text:    "[Rows \"3\"]\n[Columns \"3\"]\n[Starter \"x\"]\n\n1. b2 a1 2. d4 *\n",
+			target:  ErrTurnInvalid,
+			message: "move 2. d4",
+		},
+		{
+			name:    "game over",
+			text:    "[Rows \"3\"]\n[Columns \"3\"]\n[Starter \"x\"]\n\n1. a1 b1 2. a2 b2 3. a3 b3 1-0\n",
+			target:  ErrTurnInvalid,
+			message: "move 3... b3",
+		},
+		{
+			name:   "result mismatch",
+			text:   "[Rows \"3\"]\n[Columns \"3\"]\n[Starter \"x\"]\n\n1. a1 b1 2. a2 b2 3. a3 0-1\n",
+			target: ErrNotationInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			record, err := ParseRecord(tc.text)
+			if err != nil {
+				t.Fatalf("ParseRecord() returned unexpected error: %v", err)
+			}
+			_, err = Replay(record)
+			if !errors.Is(err, tc.target) {
+				t.Fatalf("Replay() returned error %v, want %v", err, tc.target)
+			}
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("Replay() returned error %q, want it to contain %q", err, tc.message)
+			}
+		})
+	}
+}
+
+func TestReplay_WithBot(t *testing.T) {
+	record, err := ParseRecord("[Rows \"3\"]\n[Columns \"3\"]\n[Starter \"x\"]\n\n1. b2 a1 2. c3 *\n")
+	if err != nil {
+		t.Fatalf("ParseRecord() returned unexpected error: %v", err)
+	}
+	obs := &botTurnObserver{}
+	g, err := Replay(record, WithHardBot(PlayerTwo), WithObserver(obs), WithConcurrencySafety())
+	if err != nil {
+		t.Fatalf("Replay() returned unexpected error: %v", err)
+	}
+	if got, want := len(g.Turns()), len(record.Turns); got != want {
+		t.Errorf("Replay() has %d turns, want %d", got, want)
+	}
+	if !g.IsBotTurn() {
+		t.Fatal("Replay() is not awaiting turn from bot")
+	}
+	if _, _, err = g.AllowBotTurn(); err != nil {
+		t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+	}
+	if len(obs.bots) != 1 {
+		t.Errorf("BotTurnFinished() called %d times, want 1", len(obs.bots))
+	}
+}
+
+func TestParseRecord_Invalid(t *testing.T) {
+	for _, text := range []string{
+		"[Rows 3]\n",
+		"[Rows \"3\"\n",
+		"[Rows \"x\"]\n",
+		"[Starter \"z\"]\n",
+		"[Result \"2-0\"]\n",
+		"[Variant \"giveaway\"]\n",
+		"[Date \"01/03/2025\"]\n",
+		"[Starter \"x\"]\n\n1. b2 [Rows \"3\"]\n",
+		"[Starter \"x\"]\n\n1. b2 1-0 a1\n",
+		"[Starter \"x\"]\n[Result \"0-1\"]\n\n1. b2 1-0\n",
+		"[Starter \"-\"]\n\n1. b2\n",
+		"[Starter \"x\"]\n\nx. b2\n",
+		"[Starter \"x\"]\n\n1. b0\n",
+	} {
+		t.Run(text, func(t *testing.T) {
+			if _, err := ParseRecord(text); !errors.Is(err, ErrNotationInvalid) {
+				t.Errorf("ParseRecord() returned error %v, want %v", err, ErrNotationInvalid)
+			}
+		})
+	}
+}
+
+// equalRecords returns whether the given Records are equal
+func equalRecords(a, b Record) bool {
+	return a.Columns == b.Columns && a.Date.Equal(b.Date) && a.Gravity == b.Gravity && a.Misere == b.Misere &&
+		a.PlayerOne == b.PlayerOne && a.PlayerTwo == b.PlayerTwo && a.Position == b.Position && a.Result == b.Result &&
+		a.Rows == b.Rows && a.Starter == b.Starter && slices.Equal(a.Turns, b.Turns) && a.WinLength == b.WinLength
+}
+
+func mustParseCell(t *testing.T, coords string) Cell {
+	t.Helper()
+	cell, err := ParseCell(coords)
+	if err != nil {
+		t.Fatalf("ParseCell(%q) returned unexpected error: %v", coords, err)
+	}
+	return cell
+}
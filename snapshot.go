@@ -0,0 +1,141 @@
+package tictactoe
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// SnapshotVersion is the version of Snapshot produced by Game.Snapshot and supported by WithSnapshot.
+//
+// Snapshots of any earlier version are also supported by WithSnapshot, however, those of any later version are rejected
+// as they may contain state that would otherwise be lost (e.g. Gravity and Misere were added in version 3).
+const SnapshotVersion = 3
+
+type (
+	// Snapshot represents the serializable state of a Game that can be used to restore an identical Game
+	Snapshot struct {
+		// Version is the version of Snapshot, which is always SnapshotVersion when taken by Game
+		Version int `json:"version"`
+		// Board is the Board on which the first of Turns was played, where the Game was started using WithBoard.
+		//
+		// Board is nil if the Game was started with an empty Board.
+		Board Board `json:"board,omitempty"`
+		// Bot is the Bot opponent, where applicable.
+		//
+		// Deprecated: Bot is only read from a Snapshot with version 1. Use Bots instead.
+		Bot *SnapshotBot `json:"bot,omitempty"`
+		// Bots contains the Bot controlling each Player, where applicable, ordered by Player
+		Bots []SnapshotBot `json:"bots,omitempty"`
+		// Columns is the number of columns on the Board
+		Columns uint8 `json:"columns"`
+		// Gravity is whether the Game is played with gravity (see WithGravity)
+		Gravity bool `json:"gravity,omitempty"`
+		// Misere is whether the Game is played under misère rules (see WithMisere)
+		Misere bool `json:"misere,omitempty"`
+		// Player is the current Player (see Game.Player)
+		Player Player `json:"player"`
+		// Rows is the number of rows on the Board
+		Rows uint8 `json:"rows"`
+		// Starter is the Player who was to take the first of Turns, which is zero if the Game was over when started
+		Starter Player `json:"starter"`
+		// State is the current State
+		State State `json:"state"`
+		// Turns contains each Turn played in order
+		Turns []Turn `json:"turns"`
+		// WinLength is the number of consecutive cells a Player must occupy to win
+		WinLength uint8 `json:"winLength"`
+	}
+
+	// SnapshotBot represents a Bot within a Snapshot
+	SnapshotBot struct {
+		// Name is the name of the Bot
+		Name string `json:"name"`
+		// Player is the Player for which the Bot is playing
+		Player Player `json:"player"`
+	}
+)
+
+// Restore returns a new Game restored from the given JSON encoding of a Snapshot (e.g. from Game.MarshalJSON),
+// optionally customized by providing options.
+//
+// Any options are applied before WithSnapshot so that a custom Bot can be passed using WithBot and any additional
+// winning Condition using WithCondition or WithConditions.
+//
+// An ErrOptionInvalid is returned if data cannot be decoded or contains an invalid Snapshot. Otherwise, the same errors
+// as Start may be returned.
+func Restore(data []byte, opts ...Option) (Game, error) {
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmtInvalidOptionErr("WithSnapshot", err)
+	}
+	return Start(append(append([]Option{}, opts...), WithSnapshot(snapshot))...)
+}
+
+func setSnapshot(g *game, snapshot Snapshot) error {
+	if snapshot.Version < 1 || snapshot.Version > SnapshotVersion {
+		return fmt.Errorf("unsupported version: %d", snapshot.Version)
+	}
+
+	board := snapshot.Board.Copy()
+	if board == nil {
+		if snapshot.Rows < MinSize || snapshot.Columns < MinSize {
+			return fmt.Errorf("rows and columns must be at least: %d", MinSize)
+		}
+		board = NewBoard(snapshot.Rows, snapshot.Columns)
+	}
+	starter, rows, cols, _, err := board.check()
+	if err != nil {
+		return err
+	}
+	if rows != snapshot.Rows || cols != snapshot.Columns {
+		return fmt.Errorf("board size (%dx%d) does not match rows and columns (%dx%d)", rows, cols, snapshot.Rows,
+			snapshot.Columns)
+	}
+	if snapshot.WinLength < MinWinLength || snapshot.WinLength > max(rows, cols) {
+		return fmt.Errorf("win length out of range: %d", snapshot.WinLength)
+	}
+	if !snapshot.Starter.IsValidOrZero() {
+		return fmtPlayerNotFoundErr(snapshot.Starter)
+	}
+	if starter > 0 && snapshot.Starter != starter {
+		return fmt.Errorf("board contains unfair advantage for player: %d", starter.Next())
+	}
+
+	bots := snapshot.Bots
+	if snapshot.Version == 1 && snapshot.Bot != nil {
+		bots = []SnapshotBot{*snapshot.Bot}
+	}
+	for _, b := range bots {
+		if !b.Player.IsValid() {
+			return fmtPlayerNotFoundErr(b.Player)
+		}
+		if bot := g.bots[b.Player]; bot == nil {
+			if bot, err = NewBot(b.Name, b.Player); err != nil {
+				return err
+			}
+			if g.bots == nil {
+				g.bots = make(map[Player]Bot, 2)
+			}
+			g.bots[b.Player] = bot
+		} else if bot.Name() != b.Name {
+			return fmt.Errorf("bot %q for player[%d] does not match bot %q", b.Name, b.Player, bot.Name())
+		}
+	}
+	for _, player := range Players() {
+		bot := g.bots[player]
+		if bot != nil && !slices.ContainsFunc(bots, func(b SnapshotBot) bool { return b.Player == player }) {
+			return fmt.Errorf("bot %q for player[%d] not found", bot.Name(), player)
+		}
+	}
+
+	if err = setBoard(g, board); err != nil {
+		return err
+	}
+	g.gravity = snapshot.Gravity
+	g.misere = snapshot.Misere
+	g.player = snapshot.Starter
+	g.restore = &snapshot
+	g.winLength = snapshot.WinLength
+	return nil
+}
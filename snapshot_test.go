@@ -0,0 +1,142 @@
+package tictactoe
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRestore(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		opts  []Option
+		cells []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "ongoing",
+			opts:  []Option{WithNormalBot(PlayerTwo)},
+			cells: []string{"b2"},
+		},
+		{
+			name:  "won",
+			opts:  []Option{WithDimensions(3, 4), WithWinLength(3)},
+			cells: []string{"a1", "a2", "b1", "b2", "c1"},
+		},
+		{
+			name:  "gravity",
+			opts:  []Option{WithGravity(), WithSize(4)},
+			cells: []string{"a4", "a3", "b4"},
+		},
+		{
+			name:  "misere",
+			opts:  []Option{WithMisere(), WithSize(4)},
+			cells: []string{"a4", "b4"},
+		},
+		{
+			name:  "position",
+			opts:  []Option{WithPosition("xo./.x./..o x")},
+			cells: []string{"c1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(tc.opts...)
+			for _, coords := range tc.cells {
+				if _, _, err := g.Play(Turn{Cell: mustParseCell(t, coords), Player: g.Player()}); err != nil {
+					t.Fatalf("Play() returned unexpected error: %v", err)
+				}
+				if _, _, err := g.AllowBotTurn(); err != nil {
+					t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+				}
+			}
+			data, err := json.Marshal(g)
+			if err != nil {
+				t.Fatalf("Marshal() returned unexpected error: %v", err)
+			}
+
+			restored, err := Restore(data)
+			if err != nil {
+				t.Fatalf("Restore() returned unexpected error: %v", err)
+			}
+			if got, want := restored.Snapshot(), g.Snapshot(); !equalSnapshots(got, want) {
+				t.Errorf("Restore() has Snapshot() %+v, want %+v", got, want)
+			}
+			if got, want := Format(restored), Format(g); got != want {
+				t.Errorf("Format(Restore()) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRestore_Version1(t *testing.T) {
+	data := []byte(`{"version":1,"bot":{"name":"easy","player":2},"columns":3,"player":1,"rows":3,"starter":1,` +
+		`"state":0,"turns":[{"column":1,"row":1,"player":1},{"column":0,"row":0,"player":2}],"winLength":3}`)
+	g, err := Restore(data)
+	if err != nil {
+		t.Fatalf("Restore() returned unexpected error: %v", err)
+	}
+	if bot := g.Bot(PlayerTwo); bot == nil || bot.Name() != "easy" {
+		t.Errorf("Restore() has Bot(PlayerTwo) %v, want %q", bot, "easy")
+	}
+	if got := g.Snapshot(); got.Version != SnapshotVersion || len(got.Bots) != 1 || got.Bot != nil {
+		t.Errorf("Restore() has Snapshot() %+v, want version %d with bot in Bots", got, SnapshotVersion)
+	}
+	if got, want := Format(g), "o../.x./... x"; got != want {
+		t.Errorf("Format(Restore()) = %q, want %q", got, want)
+	}
+}
+
+func TestRestore_Version2(t *testing.T) {
+	data := []byte(`{"version":2,"bots":[{"name":"easy","player":2}],"columns":3,"player":1,"rows":3,"starter":1,` +
+		`"state":0,"turns":[{"column":1,"row":1,"player":1},{"column":0,"row":0,"player":2}],"winLength":3}`)
+	g, err := Restore(data)
+	if err != nil {
+		t.Fatalf("Restore() returned unexpected error: %v", err)
+	}
+	if g.HasGravity() || g.IsMisere() {
+		t.Errorf("Restore() has HasGravity(), IsMisere() %t, %t, want false, false", g.HasGravity(), g.IsMisere())
+	}
+	if got := g.Snapshot().Version; got != SnapshotVersion {
+		t.Errorf("Restore() has Snapshot() version %d, want %d", got, SnapshotVersion)
+	}
+}
+
+func TestRestore_Invalid(t *testing.T) {
+	valid := MustStart().Snapshot()
+	for _, tc := range []struct {
+		name   string
+		change func(s *Snapshot)
+	}{
+		{"version zero", func(s *Snapshot) { s.Version = 0 }},
+		{"version unsupported", func(s *Snapshot) { s.Version = SnapshotVersion + 1 }},
+		{"bot not found", func(s *Snapshot) { s.Bots = []SnapshotBot{{Name: "unknown", Player: PlayerTwo}} }},
+		{"bot player invalid", func(s *Snapshot) { s.Bots = []SnapshotBot{{Name: "easy", Player: 3}} }},
+		{"rows", func(s *Snapshot) { s.Rows = 2 }},
+		{"state", func(s *Snapshot) { s.State = StateDraw }},
+		{"turn", func(s *Snapshot) { s.Turns = []Turn{{Cell: Cell{Column: 3}, Player: PlayerOne}} }},
+		{"win length", func(s *Snapshot) { s.WinLength = 4 }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			snapshot := valid
+			tc.change(&snapshot)
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				t.Fatalf("Marshal() returned unexpected error: %v", err)
+			}
+			if _, err = Restore(data); !errors.Is(err, ErrOptionInvalid) {
+				t.Errorf("Restore() returned error %v, want %v", err, ErrOptionInvalid)
+			}
+		})
+	}
+}
+
+// equalSnapshots returns whether the given Snapshots are equal
+func equalSnapshots(a, b Snapshot) bool {
+	return a.Version == b.Version && slices.EqualFunc(a.Board, b.Board, slices.Equal) &&
+		slices.Equal(a.Bots, b.Bots) && a.Columns == b.Columns && a.Gravity == b.Gravity && a.Misere == b.Misere &&
+		a.Player == b.Player && a.Rows == b.Rows && a.Starter == b.Starter && a.State == b.State &&
+		slices.Equal(a.Turns, b.Turns) && a.WinLength == b.WinLength
+}
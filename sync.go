@@ -0,0 +1,215 @@
+package tictactoe
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// syncGame is a Game that guards a game with a lock so that it's safe for concurrent use
+type syncGame struct {
+	game *game
+	mu   sync.RWMutex
+}
+
+func (s *syncGame) AllowBotTurn() (State, Player, error) {
+	return s.AllowBotTurnContext(context.Background())
+}
+
+func (s *syncGame) AllowBotTurnContext(ctx context.Context) (State, Player, error) {
+	s.mu.Lock()
+	g := s.game
+	if !g.IsBotTurn() {
+		defer s.mu.Unlock()
+		return g.state, g.player, nil
+	}
+	bot, board, player := g.bots[g.player], g.board.Copy(), g.player
+	g.notify(func(o Observer) {
+		o.BotTurnStarted(g, bot)
+	})
+	s.mu.Unlock()
+
+	// Lock is released while the Bot takes its turn, so it's passed s rather than g to ensure any reads are guarded
+	cell, err := botTurn(ctx, bot, board, s)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil && (!g.IsBotTurn() || g.player != player || !slices.EqualFunc(g.board, board, slices.Equal)) {
+		err = errors.New("game changed while taking turn")
+	}
+	return g.playBotTurn(bot, cell, err)
+}
+
+func (s *syncGame) Board() Board {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Board()
+}
+
+func (s *syncGame) Bot(player Player) Bot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Bot(player)
+}
+
+func (s *syncGame) Columns() uint8 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Columns()
+}
+
+func (s *syncGame) Conditions() Conditions {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Conditions()
+}
+
+func (s *syncGame) HasGravity() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.HasGravity()
+}
+
+func (s *syncGame) IsBotTurn() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.IsBotTurn()
+}
+
+func (s *syncGame) IsMisere() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.IsMisere()
+}
+
+func (s *syncGame) LastTurn() (Turn, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.LastTurn()
+}
+
+func (s *syncGame) LegalCells() Cells {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.LegalCells()
+}
+
+func (s *syncGame) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.MarshalJSON()
+}
+
+func (s *syncGame) MaxTurns() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.MaxTurns()
+}
+
+func (s *syncGame) Play(turn Turn) (State, Player, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.game.Play(turn)
+}
+
+func (s *syncGame) Player() Player {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Player()
+}
+
+func (s *syncGame) PlayerAt(cell Cell) (Player, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.PlayerAt(cell)
+}
+
+func (s *syncGame) Rand() *rand.Rand {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Rand()
+}
+
+func (s *syncGame) Redo() (State, Player, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.game.Redo()
+}
+
+func (s *syncGame) RemainingTurns() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.RemainingTurns()
+}
+
+func (s *syncGame) Resign(player Player) (State, Player, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.game.Resign(player)
+}
+
+func (s *syncGame) Rows() uint8 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Rows()
+}
+
+func (s *syncGame) Size() uint8 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Size()
+}
+
+func (s *syncGame) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Snapshot()
+}
+
+func (s *syncGame) State() State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.State()
+}
+
+func (s *syncGame) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.String()
+}
+
+func (s *syncGame) Turns() []Turn {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.Turns()
+}
+
+func (s *syncGame) Undo() (State, Player, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.game.Undo()
+}
+
+func (s *syncGame) WinLength() uint8 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.WinLength()
+}
+
+func (s *syncGame) WinningCells() Cells {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.game.WinningCells()
+}
+
+// unwrapGame returns the game underlying the given Game, which must have been returned by Start.
+//
+// Any lock is not acquired so unwrapGame must only be used before Game has been shared.
+func unwrapGame(g Game) *game {
+	if s, ok := g.(*syncGame); ok {
+		return s.game
+	}
+	return g.(*game)
+}
@@ -0,0 +1,163 @@
+package tictactoe
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// blockingBot is a Bot that waits to be released before taking the first empty Cell, allowing a test to change Game
+// while it's taking its turn
+type blockingBot struct {
+	player  Player
+	release chan struct{}
+	started chan struct{}
+}
+
+func newBlockingBot(player Player) *blockingBot {
+	return &blockingBot{
+		player:  player,
+		release: make(chan struct{}),
+		started: make(chan struct{}),
+	}
+}
+
+func (b *blockingBot) MaxSize() uint8 {
+	return MaxSize
+}
+
+func (b *blockingBot) Name() string {
+	return "blocking"
+}
+
+func (b *blockingBot) Player() Player {
+	return b.player
+}
+
+func (b *blockingBot) Turn(board Board, _ Game) (Cell, error) {
+	close(b.started)
+	<-b.release
+	return board.FindEmpty()[0], nil
+}
+
+// botTurnObserver is an Observer that records each Bot passed to BotTurnFinished
+type botTurnObserver struct {
+	NopObserver
+	bots []Bot
+}
+
+func (o *botTurnObserver) BotTurnFinished(_ Game, bot Bot, _ Cell, _ error) {
+	o.bots = append(o.bots, bot)
+}
+
+func TestConcurrencySafety(t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 200
+	)
+	g := MustStart(WithConcurrencySafety(), WithEasyBot(PlayerTwo), WithSeed(1), WithSize(4))
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				switch (i + j) % 6 {
+				case 0:
+					if cells := g.LegalCells(); len(cells) > 0 {
+						_, _, _ = g.Play(Turn{Cell: cells.RandomFrom(g.Rand()), Player: PlayerOne})
+					}
+				case 1:
+					_ = g.Board().String()
+				case 2:
+					_ = g.Turns()
+				case 3:
+					if _, _, err := g.AllowBotTurn(); err != nil && !errors.Is(err, ErrBot) {
+						t.Errorf("AllowBotTurn() returned unexpected error: %v", err)
+					}
+				case 4:
+					_ = g.Rand().Intn(9)
+				case 5:
+					if _, _, err := g.Undo(); err != nil && !errors.Is(err, ErrNothingToUndo) {
+						t.Errorf("Undo() returned unexpected error: %v", err)
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if turns, board := g.Turns(), g.Board(); len(turns) != countTaken(board) {
+		t.Errorf("Turns() has %d turns but Board() has %d taken cells", len(turns), countTaken(board))
+	}
+}
+
+func TestConcurrencySafety_ChangedDuringBotTurn(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		change func(g Game) error
+		player Player
+		state  State
+	}{
+		{
+			name: "resign",
+			change: func(g Game) error {
+				_, _, err := g.Resign(PlayerOne)
+				return err
+			},
+			player: PlayerOne,
+			state:  StateForfeited,
+		},
+		{
+			name: "undo",
+			change: func(g Game) error {
+				_, _, err := g.Undo()
+				return err
+			},
+			player: PlayerOne,
+			state:  StateAwaitingTurn,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bot, obs := newBlockingBot(PlayerTwo), &botTurnObserver{}
+			g := MustStart(WithConcurrencySafety(), WithBot(bot), WithObserver(obs))
+			if _, _, err := g.Play(Turn{Player: PlayerOne}); err != nil {
+				t.Fatalf("Play() returned unexpected error: %v", err)
+			}
+
+			done := make(chan error)
+			go func() {
+				_, _, err := g.AllowBotTurn()
+				done <- err
+			}()
+			<-bot.started
+			if err := tc.change(g); err != nil {
+				t.Fatalf("change returned unexpected error: %v", err)
+			}
+			close(bot.release)
+
+			if err := <-done; !errors.Is(err, ErrBot) {
+				t.Errorf("AllowBotTurn() returned error %v, want %v", err, ErrBot)
+			}
+			if player, state := g.Player(), g.State(); player != tc.player || state != tc.state {
+				t.Errorf("Player(), State() = %d, %v, want %d, %v", player, state, tc.player, tc.state)
+			}
+			if len(obs.bots) != 1 || obs.bots[0] != bot {
+				t.Errorf("BotTurnFinished() called with bots %v, want [%v]", obs.bots, bot)
+			}
+		})
+	}
+}
+
+// countTaken returns the number of cells within board that contain a Player
+func countTaken(board Board) (count int) {
+	for _, cols := range board {
+		for _, player := range cols {
+			if player > 0 {
+				count++
+			}
+		}
+	}
+	return
+}
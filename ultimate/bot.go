@@ -0,0 +1,188 @@
+package ultimate
+
+import (
+	"context"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"math"
+)
+
+// Bot represents a machine-controlled player of a Game
+type Bot interface {
+	// Name returns the name of the Bot
+	Name() string
+	// Player returns the Player for which the Bot is playing
+	Player() tictactoe.Player
+	// Turn allows the Bot to check Game for the best possible Move, which must be one of Game.LegalMoves.
+	//
+	// Turn is only ever called if there is at least one legal Move, however, it must stop checking Game and return the
+	// error of the given context as soon as possible once context is done.
+	Turn(ctx context.Context, game Game) (Move, error)
+}
+
+const (
+	nameHeuristic = "heuristic"
+	nameRandom    = "random"
+)
+
+const (
+	// heuristicBlock is the value of taking a cell in which the opponent would have won the sub-board, multiplied by
+	// the weight of the sub-board
+	heuristicBlock = 20
+	// heuristicClaim is the value of winning a sub-board, multiplied by the weight of the sub-board
+	heuristicClaim = 25
+	// heuristicFreeChoice is the cost of allowing the opponent to choose any sub-board for their next turn
+	heuristicFreeChoice = 15
+	// heuristicLoss is the cost of allowing the opponent to win the game on their next turn
+	heuristicLoss = 1000
+)
+
+type heuristicBot struct {
+	player tictactoe.Player
+}
+
+func (b *heuristicBot) Name() string {
+	return nameHeuristic
+}
+
+func (b *heuristicBot) Player() tictactoe.Player {
+	return b.player
+}
+
+func (b *heuristicBot) Turn(ctx context.Context, game Game) (Move, error) {
+	p := positionOf(game)
+	var (
+		best      []Move
+		bestScore = math.MinInt
+	)
+	for _, move := range p.legalMoves() {
+		if err := ctx.Err(); err != nil {
+			return Move{}, err
+		}
+		score, won := b.score(p, move)
+		if won {
+			return move, nil
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore = []Move{move}, score
+		case score == bestScore:
+			best = append(best, move)
+		}
+	}
+	return best[game.Rand().Intn(len(best))], nil
+}
+
+// score returns the value of taking the given Move within position, and whether it wins the game, based on the
+// sub-boards that it claims or blocks and the best reply available to the opponent.
+func (b *heuristicBot) score(p position, move Move) (score int, won bool) {
+	opponent := b.player.Next()
+	board := p.boards[index(move.Board)]
+	board[move.Cell.Row][move.Cell.Column] = opponent
+	blocks := p.conditions.IsWinningTurn(board, tictactoe.Turn{Cell: move.Cell, Player: opponent})
+	board[move.Cell.Row][move.Cell.Column] = 0
+
+	next := p.clone()
+	boardWon, won := next.place(move, b.player)
+	if won {
+		return math.MaxInt, true
+	}
+	score = weight(move.Cell)
+	if boardWon {
+		score += heuristicClaim * weight(move.Board)
+	} else if blocks {
+		score += heuristicBlock * weight(move.Board)
+	}
+	if next.isOver() {
+		return score, false
+	}
+	if next.free {
+		score -= heuristicFreeChoice
+	}
+
+	var oppBest int
+	for _, reply := range next.legalMoves() {
+		after := next.clone()
+		if oppBoardWon, oppWon := after.place(reply, opponent); oppWon {
+			return score - heuristicLoss, false
+		} else if oppBoardWon {
+			oppBest = max(oppBest, heuristicClaim*weight(reply.Board))
+		}
+	}
+	return score - oppBest, false
+}
+
+// NewHeuristicBot returns a new Bot that values each Move based on the sub-boards that it claims or blocks, the
+// strength of the cells involved, and the sub-board to which it sends the opponent
+func NewHeuristicBot(player tictactoe.Player) Bot {
+	return &heuristicBot{player}
+}
+
+type randomBot struct {
+	player tictactoe.Player
+}
+
+func (b *randomBot) Name() string {
+	return nameRandom
+}
+
+func (b *randomBot) Player() tictactoe.Player {
+	return b.player
+}
+
+func (b *randomBot) Turn(_ context.Context, game Game) (Move, error) {
+	moves := game.LegalMoves()
+	return moves[game.Rand().Intn(len(moves))], nil
+}
+
+// NewRandomBot returns a new Bot that takes a random legal Move
+func NewRandomBot(player tictactoe.Player) Bot {
+	return &randomBot{player}
+}
+
+// BotNames returns the names of all built-in bots in alphabetical order
+func BotNames() []string {
+	return []string{nameHeuristic, nameRandom}
+}
+
+// NewBot returns a new built-in Bot with the given name playing for player.
+//
+// An ErrBotNotFound is returned if no Bot is built-in with name.
+func NewBot(name string, player tictactoe.Player) (Bot, error) {
+	switch name {
+	case nameHeuristic:
+		return NewHeuristicBot(player), nil
+	case nameRandom:
+		return NewRandomBot(player), nil
+	default:
+		return nil, errs.BotNotFound(name)
+	}
+}
+
+// positionOf returns a position derived from the given Game so that a Bot can search it
+func positionOf(game Game) position {
+	p := newPosition()
+	for i := range p.boards {
+		board := cellAt(i)
+		p.boards[i], _ = game.Board(board)
+		p.states[i], _, _ = game.BoardState(board)
+	}
+	p.meta = game.MetaBoard()
+	next, ok := game.NextBoard()
+	p.free, p.next = !ok, next
+	return p
+}
+
+// weight returns the number of lines passing through the given cell of a board (i.e. 4 for the center, 3 for a corner,
+// and 2 for an edge)
+func weight(cell tictactoe.Cell) int {
+	center := Size / 2
+	switch {
+	case cell.Row == center && cell.Column == center:
+		return 4
+	case cell.Row != center && cell.Column != center:
+		return 3
+	default:
+		return 2
+	}
+}
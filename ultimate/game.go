@@ -0,0 +1,481 @@
+package ultimate
+
+import (
+	"context"
+	"fmt"
+	"github.com/neocotic/go-tic-tac-toe"
+	"github.com/neocotic/go-tic-tac-toe/internal/errs"
+	"github.com/neocotic/go-tic-tac-toe/internal/variant"
+	"math/rand"
+	"strings"
+)
+
+// Size is the number of rows and columns of both the meta-board and each sub-board
+const Size uint8 = 3
+
+type (
+	// Move represents the location of a cell within a sub-board on the meta-board
+	Move struct {
+		// Board is the location of the sub-board on the meta-board
+		Board tictactoe.Cell `json:"board"`
+		// Cell is the location of the cell within the sub-board
+		Cell tictactoe.Cell `json:"cell"`
+	}
+
+	// Turn represents a turn that is either to be taken or has already been taken
+	Turn struct {
+		// Move is the location of the cell within a sub-board
+		Move
+		// Player is the Player
+		Player tictactoe.Player `json:"player"`
+	}
+)
+
+// String returns the algebraic coordinates of the sub-board followed by those of the cell within it, separated by a
+// slash (e.g. "b2/a1")
+func (m Move) String() string {
+	return tictactoe.FormatCell(m.Board) + "/" + tictactoe.FormatCell(m.Cell)
+}
+
+type (
+	// Game represents a single session of ultimate tic-tac-toe, where each cell of the meta-board holds a sub-board.
+	//
+	// A Player claims a cell of the meta-board by winning its sub-board and wins Game by claiming cells in any row,
+	// column, or diagonal of the meta-board. The location of the cell taken within a sub-board dictates the sub-board
+	// in which the opponent must take their next turn, unless that sub-board is already over, in which case they can
+	// take their turn in any sub-board that isn't.
+	//
+	// Game is not safe for concurrent use by multiple goroutines.
+	Game interface {
+		// AllowBotTurn requests a turn from a Bot, where applicable, and plays that Turn.
+		//
+		// Nothing happens if Game doesn't have StateAwaitingTurn or the current Player is not controlled by a Bot.
+		//
+		// An ErrBot is returned if the Bot fails to take their turn or their turn is invalid due to the same
+		// constraints as applied to Play.
+		AllowBotTurn() (tictactoe.State, tictactoe.Player, error)
+		// AllowBotTurnContext is the same as AllowBotTurn except that the given context is passed to the Bot so that it
+		// can be cancelled or have a deadline imposed, with any turn taken after context is done being discarded.
+		AllowBotTurnContext(ctx context.Context) (tictactoe.State, tictactoe.Player, error)
+		// Board returns a copy of the sub-board at the given cell of the meta-board.
+		//
+		// An ErrOutOfBounds is returned if board is out-of-bounds.
+		Board(board tictactoe.Cell) (tictactoe.Board, error)
+		// BoardState returns the State of the sub-board at the given cell of the meta-board and the Player who won it,
+		// where applicable.
+		//
+		// An ErrOutOfBounds is returned if board is out-of-bounds.
+		BoardState(board tictactoe.Cell) (tictactoe.State, tictactoe.Player, error)
+		// Bot returns the Bot controlling the given Player, or nil if Player is human
+		Bot(player tictactoe.Player) Bot
+		// IsBotTurn returns whether the current Player is controlled by a Bot.
+		//
+		// If Game does not have StateAwaitingTurn, false will always be returned.
+		IsBotTurn() bool
+		// LastTurn returns the last Turn played, where possible
+		LastTurn() (Turn, bool)
+		// LegalMoves returns every Move that the current Player can take, being every empty cell within the sub-board
+		// dictated by the last Turn or, where they can choose, within every sub-board that isn't over.
+		//
+		// Nil is returned if Game doesn't have StateAwaitingTurn.
+		LegalMoves() []Move
+		// MetaBoard returns a copy of the meta-board, where each cell contains the Player who won its sub-board, if
+		// any
+		MetaBoard() tictactoe.Board
+		// NextBoard returns the cell of the meta-board whose sub-board the current Player must take their turn in.
+		//
+		// False is returned if they can choose any sub-board that isn't over or Game doesn't have StateAwaitingTurn.
+		NextBoard() (tictactoe.Cell, bool)
+		// Play takes the given Turn and returns the resulting State and Player.
+		//
+		// The resulting Player will vary depending on State:
+		//  - For StateAwaitingTurn it's the Player to take the next turn
+		//  - For StateDraw it's zero
+		//  - For StateWon it's the given Player who's won
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game doesn't have StateAwaitingTurn
+		//  - ErrOutOfBounds if either cell of Turn's Move is out-of-bounds
+		//  - ErrPlayerNotFound if Turn's Player is invalid
+		//  - ErrTurnInvalid if Turn is invalid (e.g. not turn of Player, wrong sub-board, cell taken)
+		Play(turn Turn) (tictactoe.State, tictactoe.Player, error)
+		// Player returns the current Player, where appropriate.
+		//
+		// The Player will vary depending on Game's State:
+		//  - For StateAwaitingTurn it's the Player to take the next turn
+		//  - For StateDraw it's zero
+		//  - For StateWon it's the winning Player
+		//  - For StateForfeited it's the Player who resigned
+		Player() tictactoe.Player
+		// Rand returns the source of randomness used by Game and any built-in Bot.
+		//
+		// It's safe for concurrent use, except for its Read method, so that a Bot can use it while Game is otherwise in
+		// use.
+		Rand() *rand.Rand
+		// Resign ends Game early with the given Player forfeiting and returns the resulting State and Player.
+		//
+		// An error is returned in following cases:
+		//  - ErrGameOver if Game doesn't have StateAwaitingTurn
+		//  - ErrPlayerNotFound if Player is invalid
+		Resign(player tictactoe.Player) (tictactoe.State, tictactoe.Player, error)
+		// State returns the current State
+		State() tictactoe.State
+		// String returns an ASCII representation of every sub-board, arranged as they are on the meta-board
+		String() string
+		// Turns returns a copy of each Turn already played
+		Turns() []Turn
+		// WinningCells returns the cells of the meta-board that resulted in the win, where possible.
+		//
+		// Nil is returned if Game does not have StateWon.
+		WinningCells() tictactoe.Cells
+	}
+
+	game struct {
+		variant.Base[Bot]
+		position
+		turns        []Turn
+		winningCells tictactoe.Cells
+	}
+)
+
+func (g *game) AllowBotTurn() (tictactoe.State, tictactoe.Player, error) {
+	return g.AllowBotTurnContext(context.Background())
+}
+
+func (g *game) AllowBotTurnContext(ctx context.Context) (tictactoe.State, tictactoe.Player, error) {
+	if !g.IsBotTurn() {
+		return g.State(), g.Player(), nil
+	}
+	bot := g.Bot(g.Player())
+	move, err := bot.Turn(ctx, g)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return g.State(), g.Player(), errs.Bot(bot.Name(), err)
+	}
+	if _, _, err = g.play(Turn{Move: move, Player: g.Player()}, true); err != nil {
+		err = errs.Bot(bot.Name(), err)
+	}
+	return g.State(), g.Player(), err
+}
+
+func (g *game) Board(board tictactoe.Cell) (tictactoe.Board, error) {
+	if err := validateBounds(board); err != nil {
+		return nil, err
+	}
+	return g.boards[index(board)].Copy(), nil
+}
+
+func (g *game) BoardState(board tictactoe.Cell) (tictactoe.State, tictactoe.Player, error) {
+	if err := validateBounds(board); err != nil {
+		return 0, 0, err
+	}
+	return g.states[index(board)], g.meta[board.Row][board.Column], nil
+}
+
+func (g *game) LastTurn() (Turn, bool) {
+	if l := len(g.turns); l == 0 {
+		return Turn{}, false
+	} else {
+		return g.turns[l-1], true
+	}
+}
+
+func (g *game) LegalMoves() []Move {
+	if g.State() != tictactoe.StateAwaitingTurn {
+		return nil
+	}
+	return g.legalMoves()
+}
+
+func (g *game) MetaBoard() tictactoe.Board {
+	return g.meta.Copy()
+}
+
+func (g *game) NextBoard() (tictactoe.Cell, bool) {
+	if g.State() != tictactoe.StateAwaitingTurn || g.free {
+		return tictactoe.Cell{}, false
+	}
+	return g.next, true
+}
+
+func (g *game) Play(turn Turn) (tictactoe.State, tictactoe.Player, error) {
+	return g.play(turn, false)
+}
+
+func (g *game) String() string {
+	var sb strings.Builder
+	for row := 0; row < int(Size*Size); row++ {
+		if row > 0 && row%int(Size) == 0 {
+			for board := 0; board < int(Size); board++ {
+				if board > 0 {
+					sb.WriteRune('+')
+				}
+				sb.WriteString(strings.Repeat("-", 2*int(Size)+1))
+			}
+			sb.WriteRune('\n')
+		}
+		for col := 0; col < int(Size*Size); col++ {
+			if col > 0 && col%int(Size) == 0 {
+				sb.WriteString(" |")
+			}
+			sb.WriteRune(' ')
+			if player := g.boards[row/int(Size)*int(Size)+col/int(Size)][row%int(Size)][col%int(Size)]; player > 0 {
+				sb.WriteString(player.String())
+			} else {
+				sb.WriteRune('.')
+			}
+		}
+		if row < int(Size*Size)-1 {
+			sb.WriteRune('\n')
+		}
+	}
+	return sb.String()
+}
+
+func (g *game) Turns() []Turn {
+	return append([]Turn(nil), g.turns...)
+}
+
+func (g *game) WinningCells() tictactoe.Cells {
+	return append(tictactoe.Cells(nil), g.winningCells...)
+}
+
+func (g *game) play(turn Turn, allowBotTurn bool) (tictactoe.State, tictactoe.Player, error) {
+	if err := g.validateTurn(turn, allowBotTurn); err != nil {
+		return g.State(), g.Player(), err
+	}
+
+	g.turns = append(g.turns, turn)
+	if _, won := g.place(turn.Move, turn.Player); won {
+		g.SetState(tictactoe.StateWon, turn.Player)
+		g.winningCells = g.conditions.WinningCells(g.meta, tictactoe.Turn{Cell: turn.Board, Player: turn.Player})
+	} else if g.isOver() {
+		g.SetState(tictactoe.StateDraw, 0)
+	} else {
+		g.SetState(tictactoe.StateAwaitingTurn, turn.Player.Next())
+	}
+	return g.State(), g.Player(), nil
+}
+
+func (g *game) validateTurn(turn Turn, allowBotTurn bool) error {
+	if err := validateBounds(turn.Board); err != nil {
+		return err
+	}
+	if err := validateBounds(turn.Cell); err != nil {
+		return err
+	}
+	if err := g.ValidateTurn(turn.Player, allowBotTurn); err != nil {
+		return err
+	}
+	if !g.free && turn.Board != g.next {
+		return errs.InvalidTurn(fmt.Sprintf("board[%d,%d] must be played instead of board[%d,%d]", g.next.Row,
+			g.next.Column, turn.Board.Row, turn.Board.Column))
+	}
+	if state := g.states[index(turn.Board)]; state != tictactoe.StateAwaitingTurn {
+		return errs.InvalidTurn(fmt.Sprintf("board[%d,%d] is over", turn.Board.Row, turn.Board.Column))
+	}
+	if existing := g.boards[index(turn.Board)][turn.Cell.Row][turn.Cell.Column]; existing > 0 {
+		return errs.InvalidTurn(fmt.Sprintf("board[%d,%d] cell[%d,%d] already taken by player %d", turn.Board.Row,
+			turn.Board.Column, turn.Cell.Row, turn.Cell.Column, existing))
+	}
+	return nil
+}
+
+// MustStart is a convenient shorthand for calling Start whilst panicking if it returns an error
+func MustStart(opts ...Option) Game {
+	if g, err := Start(opts...); err != nil {
+		panic(err)
+	} else {
+		return g
+	}
+}
+
+// Start returns a new Game, optionally customized by providing options.
+//
+// An ErrOptionInvalid is returned if an Option is passed that was given an invalid argument.
+func Start(opts ...Option) (Game, error) {
+	g := &game{
+		Base:     variant.NewBase[Bot](),
+		position: newPosition(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+
+	g.Start()
+	return g, nil
+}
+
+// Option is used to customize Game
+type Option func(g *game) error
+
+// WithBot customizes a Game so that the Player of the given Bot is controlled by it.
+//
+// A Game can have a Bot for each Player, allowing two bots to play each other. This option is ignored if preceded by
+// another bot-controlling option for the same Player (e.g. WithHeuristicBot).
+//
+// An ErrOptionInvalid is returned by the option if bot has an invalid Player.
+func WithBot(bot Bot) Option {
+	return withBot(bot, "WithBot")
+}
+
+// WithHeuristicBot is a convenient shorthand for WithBot(NewHeuristicBot(player)).
+//
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithRandomBot).
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithHeuristicBot(player tictactoe.Player) Option {
+	return withBot(NewHeuristicBot(player), "WithHeuristicBot")
+}
+
+// WithRand customizes a Game to use the given source of randomness, including by any built-in Bot.
+//
+// Game guards r so that it's safe for concurrent use, so r must no longer be used directly once Game has started.
+//
+// An ErrOptionInvalid is returned by the option if r is nil.
+func WithRand(r *rand.Rand) Option {
+	return func(g *game) error {
+		return g.SetRand(r, "WithRand")
+	}
+}
+
+// WithRandomBot is a convenient shorthand for WithBot(NewRandomBot(player)).
+//
+// This option is ignored if preceded by another bot-controlling option for the same Player (e.g. WithHeuristicBot).
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithRandomBot(player tictactoe.Player) Option {
+	return withBot(NewRandomBot(player), "WithRandomBot")
+}
+
+// WithSeed customizes a Game to use a source of randomness with the given seed, including by any built-in Bot, so that
+// the same random decisions are made whenever the same seed is used
+func WithSeed(seed int64) Option {
+	return func(g *game) error {
+		g.SetSeed(seed)
+		return nil
+	}
+}
+
+// WithStarterPlayer customizes a Game to start with the given Player.
+//
+// This option is ignored if preceded by another WithStarterPlayer.
+//
+// An ErrOptionInvalid is returned by the option if player is invalid.
+func WithStarterPlayer(player tictactoe.Player) Option {
+	return func(g *game) error {
+		return g.SetStarter(player, "WithStarterPlayer")
+	}
+}
+
+func withBot(bot Bot, option string) Option {
+	return func(g *game) error {
+		return g.SetBot(bot, option)
+	}
+}
+
+// position contains every sub-board and the meta-board along with the sub-board in which the next turn must be taken,
+// allowing turns to be placed without any of the validation of Game (e.g. when a Bot is searching)
+type position struct {
+	boards     []tictactoe.Board
+	conditions tictactoe.Conditions
+	free       bool
+	meta       tictactoe.Board
+	next       tictactoe.Cell
+	states     []tictactoe.State
+}
+
+// clone returns a deep copy of position
+func (p position) clone() position {
+	c := p
+	c.boards = make([]tictactoe.Board, len(p.boards))
+	for i, board := range p.boards {
+		c.boards[i] = board.Copy()
+	}
+	c.meta = p.meta.Copy()
+	c.states = append([]tictactoe.State(nil), p.states...)
+	return c
+}
+
+// isOver returns whether every sub-board is over
+func (p position) isOver() bool {
+	for _, state := range p.states {
+		if state == tictactoe.StateAwaitingTurn {
+			return false
+		}
+	}
+	return true
+}
+
+// legalMoves returns every Move that can be taken within position
+func (p position) legalMoves() []Move {
+	var moves []Move
+	for i, board := range p.boards {
+		boardCell := cellAt(i)
+		if p.states[i] != tictactoe.StateAwaitingTurn || (!p.free && boardCell != p.next) {
+			continue
+		}
+		for _, cell := range board.FindEmpty() {
+			moves = append(moves, Move{Board: boardCell, Cell: cell})
+		}
+	}
+	return moves
+}
+
+// place takes the given Move for player, which must be legal, and returns whether it won its sub-board and, if so,
+// whether it also won the game
+func (p *position) place(move Move, player tictactoe.Player) (boardWon, won bool) {
+	i := index(move.Board)
+	board := p.boards[i]
+	board[move.Cell.Row][move.Cell.Column] = player
+	if p.conditions.IsWinningTurn(board, tictactoe.Turn{Cell: move.Cell, Player: player}) {
+		boardWon = true
+		p.meta[move.Board.Row][move.Board.Column] = player
+		p.states[i] = tictactoe.StateWon
+		won = p.conditions.IsWinningTurn(p.meta, tictactoe.Turn{Cell: move.Board, Player: player})
+	} else if len(board.FindEmpty()) == 0 {
+		p.states[i] = tictactoe.StateDraw
+	}
+	p.next = move.Cell
+	p.free = p.states[index(p.next)] != tictactoe.StateAwaitingTurn
+	return
+}
+
+func newPosition() position {
+	p := position{
+		boards:     make([]tictactoe.Board, Size*Size),
+		conditions: tictactoe.StandardConditions(Size),
+		free:       true,
+		meta:       tictactoe.NewBoard(Size, Size),
+		states:     make([]tictactoe.State, Size*Size),
+	}
+	for i := range p.boards {
+		p.boards[i] = tictactoe.NewBoard(Size, Size)
+	}
+	return p
+}
+
+// cellAt returns the cell of the meta-board for the given index of a sub-board
+func cellAt(i int) tictactoe.Cell {
+	return tictactoe.Cell{
+		Column: uint8(i % int(Size)),
+		Row:    uint8(i / int(Size)),
+	}
+}
+
+// index returns the index of the sub-board at the given cell of the meta-board
+func index(board tictactoe.Cell) int {
+	return int(board.Row)*int(Size) + int(board.Column)
+}
+
+func validateBounds(cell tictactoe.Cell) error {
+	if cell.Row >= Size || cell.Column >= Size {
+		return errs.OutOfBounds(fmt.Sprintf("row[%d]col[%d]", cell.Row, cell.Column), Size)
+	}
+	return nil
+}
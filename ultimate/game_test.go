@@ -0,0 +1,151 @@
+package ultimate
+
+import (
+	"errors"
+	"github.com/neocotic/go-tic-tac-toe"
+	"sync"
+	"testing"
+)
+
+func TestGame_Play(t *testing.T) {
+	g := MustStart()
+	for _, move := range []Move{
+		{Board: tictactoe.Cell{}, Cell: tictactoe.Cell{Column: 1}},
+		{Board: tictactoe.Cell{Column: 1}, Cell: tictactoe.Cell{}},
+		{Board: tictactoe.Cell{}, Cell: tictactoe.Cell{Column: 2}},
+		{Board: tictactoe.Cell{Column: 2}, Cell: tictactoe.Cell{}},
+		{Board: tictactoe.Cell{}, Cell: tictactoe.Cell{}},
+	} {
+		if next, ok := g.NextBoard(); ok && next != move.Board {
+			t.Fatalf("NextBoard() = %v, want %v", next, move.Board)
+		}
+		mustPlay(t, g, move)
+	}
+	state, player, err := g.BoardState(tictactoe.Cell{})
+	if err != nil {
+		t.Fatalf("BoardState() returned unexpected error: %v", err)
+	}
+	if state != tictactoe.StateWon || player != tictactoe.PlayerOne {
+		t.Errorf("BoardState() = %v, %v, want %v, %v", state, player, tictactoe.StateWon, tictactoe.PlayerOne)
+	}
+	if got := g.MetaBoard()[0][0]; got != tictactoe.PlayerOne {
+		t.Errorf("MetaBoard()[0][0] = %v, want %v", got, tictactoe.PlayerOne)
+	}
+	if next, ok := g.NextBoard(); ok {
+		t.Errorf("NextBoard() = %v, true, want free choice as sub-board is over", next)
+	}
+	if got, want := len(g.LegalMoves()), 8*int(Size*Size)-2; got != want {
+		t.Errorf("LegalMoves() returned %d moves, want %d", got, want)
+	}
+	if g.State() != tictactoe.StateAwaitingTurn || g.Player() != tictactoe.PlayerTwo {
+		t.Errorf("Play() resulted in %v for player[%d], want %v for player[%d]", g.State(), g.Player(),
+			tictactoe.StateAwaitingTurn, tictactoe.PlayerTwo)
+	}
+}
+
+func TestGame_PlayInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		move Move
+		want error
+	}{
+		{"board out of bounds", nil, Move{Board: tictactoe.Cell{Row: Size}}, tictactoe.ErrOutOfBounds},
+		{"cell out of bounds", nil, Move{Cell: tictactoe.Cell{Column: Size}}, tictactoe.ErrOutOfBounds},
+		{"wrong board", nil, Move{Board: tictactoe.Cell{Column: 2}}, tictactoe.ErrTurnInvalid},
+		{"cell taken", nil, Move{Board: tictactoe.Cell{Column: 1}, Cell: tictactoe.Cell{Column: 1}},
+			tictactoe.ErrTurnInvalid},
+		{
+			name: "human for bot",
+			opts: []Option{WithRandomBot(tictactoe.PlayerTwo)},
+			move: Move{Board: tictactoe.Cell{Column: 1}},
+			want: tictactoe.ErrTurnInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := MustStart(tc.opts...)
+			mustPlay(t, g, Move{Board: tictactoe.Cell{Column: 1}, Cell: tictactoe.Cell{Column: 1}})
+			if _, _, err := g.Play(Turn{Move: tc.move, Player: tictactoe.PlayerTwo}); !errors.Is(err, tc.want) {
+				t.Errorf("Play() returned error %v, want %v", err, tc.want)
+			}
+			if got := len(g.Turns()); got != 1 {
+				t.Errorf("Play() resulted in %d turns, want 1", got)
+			}
+		})
+	}
+}
+
+func TestGame_PlayWrongPlayer(t *testing.T) {
+	g := MustStart(WithStarterPlayer(tictactoe.PlayerTwo))
+	if _, _, err := g.Play(Turn{Player: 3}); !errors.Is(err, tictactoe.ErrPlayerNotFound) {
+		t.Errorf("Play() returned error %v, want %v", err, tictactoe.ErrPlayerNotFound)
+	}
+	if _, _, err := g.Play(Turn{Player: tictactoe.PlayerOne}); !errors.Is(err, tictactoe.ErrTurnInvalid) {
+		t.Errorf("Play() returned error %v, want %v", err, tictactoe.ErrTurnInvalid)
+	}
+}
+
+func TestGame_Resign(t *testing.T) {
+	g := MustStart()
+	state, player, err := g.Resign(tictactoe.PlayerOne)
+	if err != nil {
+		t.Fatalf("Resign() returned unexpected error: %v", err)
+	}
+	if state != tictactoe.StateForfeited || player != tictactoe.PlayerOne {
+		t.Errorf("Resign() = %v, %v, want %v, %v", state, player, tictactoe.StateForfeited, tictactoe.PlayerOne)
+	}
+	if moves := g.LegalMoves(); moves != nil {
+		t.Errorf("LegalMoves() = %v, want nil", moves)
+	}
+	if _, _, err = g.Play(Turn{Player: tictactoe.PlayerOne}); !errors.Is(err, tictactoe.ErrGameOver) {
+		t.Errorf("Play() returned error %v, want %v", err, tictactoe.ErrGameOver)
+	}
+}
+
+func TestHeuristicBot(t *testing.T) {
+	for _, player := range tictactoe.Players() {
+		for seed := int64(1); seed <= 5; seed++ {
+			g := MustStart(WithHeuristicBot(player), WithRandomBot(player.Next()), WithSeed(seed))
+			for g.IsBotTurn() {
+				if _, _, err := g.AllowBotTurn(); err != nil {
+					t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+				}
+			}
+			if g.State() == tictactoe.StateWon && g.Player() != player {
+				t.Errorf("heuristic bot as player[%d] lost to random bot with seed %d:\n%s", player, seed, g)
+			}
+			meta := g.MetaBoard()
+			for _, cell := range g.WinningCells() {
+				if owner := meta[cell.Row][cell.Column]; owner != g.Player() {
+					t.Errorf("WinningCells() has %v owned by %v, want %v", cell, owner, g.Player())
+				}
+			}
+		}
+	}
+}
+
+func TestGame_RandConcurrent(t *testing.T) {
+	g := MustStart(WithRandomBot(tictactoe.PlayerOne), WithRandomBot(tictactoe.PlayerTwo), WithSeed(1))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = g.Rand().Intn(int(Size))
+		}
+	}()
+	for g.IsBotTurn() {
+		if _, _, err := g.AllowBotTurn(); err != nil {
+			t.Fatalf("AllowBotTurn() returned unexpected error: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+// mustPlay plays the given Move for the current Player of Game, failing the test if it returns an error
+func mustPlay(t *testing.T, g Game, move Move) {
+	t.Helper()
+	if _, _, err := g.Play(Turn{Move: move, Player: g.Player()}); err != nil {
+		t.Fatalf("Play() returned unexpected error: %v", err)
+	}
+}